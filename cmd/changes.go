@@ -0,0 +1,182 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"olive-clone-assistant-v2/internal"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	changesSince    string
+	changesAuthor   string
+	changesPaths    []string
+	changesMarkdown string
+)
+
+// changesCmd lists the commits that recent pulls brought in
+var changesCmd = &cobra.Command{
+	Use:   "changes",
+	Short: "🧾 List the commits that recent syncs pulled in",
+	Long: color.New(color.FgBlue, color.Bold).Sprint(`
+🧾 Changes Command
+==================
+
+List the incoming commits of every repository that was updated, based on the
+commits before and after each pull recorded in the sync history.
+
+• --since takes a run id from 'syncx history', a duration (24h, 7d), a date
+  or a timestamp; without it the changes of the last run are shown
+• --author and --path only list matching commits
+• --markdown writes the changelog to a file, e.g. for a stand-up
+`),
+	Run: runChanges,
+}
+
+func init() {
+	rootCmd.AddCommand(changesCmd)
+
+	changesCmd.Flags().StringVar(&changesSince, "since", "", "Run id, duration (24h, 7d), date or timestamp to list changes from (default: the last run)")
+	changesCmd.Flags().StringVar(&changesAuthor, "author", "", "Only list commits whose author matches this pattern")
+	changesCmd.Flags().StringSliceVar(&changesPaths, "path", nil, "Only list commits touching this path (repeatable)")
+	changesCmd.Flags().StringVar(&changesMarkdown, "markdown", "", "Write the changelog as Markdown to this file")
+}
+
+// ChangelogSummary is the structured output of the changes command
+type ChangelogSummary struct {
+	Since        string `json:"since"`
+	Repositories int    `json:"repositories"`
+	Commits      int    `json:"commits"`
+	Errors       int    `json:"errors"`
+}
+
+func runChanges(cmd *cobra.Command, args []string) {
+	logger := internal.NewLogger(verbose)
+
+	absDir, err := filepath.Abs(directory)
+	if err != nil {
+		logger.Error("Failed to get absolute path for %s: %v", directory, err)
+		setExitCode(ExitConfigError)
+		return
+	}
+
+	history, err := internal.LoadHistory(absDir)
+	if err != nil {
+		logger.Error("%v", err)
+		emitter.Error("%v", err)
+		setExitCode(ExitConfigError)
+		return
+	}
+	if history.Corrupt > 0 {
+		logger.Warning("Skipped %d unreadable history lines", history.Corrupt)
+	}
+	if len(history.Runs) == 0 {
+		logger.Warning("No runs recorded in %s yet", absDir)
+		emitter.Summary(ChangelogSummary{})
+		return
+	}
+
+	since, label, err := resolveChangesSince(changesSince, history, time.Now())
+	if err != nil {
+		logger.Error("%v", err)
+		emitter.Error("%v", err)
+		setExitCode(ExitConfigError)
+		return
+	}
+
+	filter := internal.ChangelogFilter{Author: changesAuthor, Paths: changesPaths}
+	changelogs := internal.ChangelogFromHistory(history, since, filter)
+
+	summary := ChangelogSummary{Since: since.Format(time.RFC3339)}
+	for _, changelog := range changelogs {
+		if changelog.Error != "" {
+			summary.Errors++
+		} else if len(changelog.Commits) == 0 {
+			continue
+		}
+		summary.Repositories++
+		summary.Commits += len(changelog.Commits)
+		emitter.Result(changelog)
+	}
+
+	displayChangelog(fmt.Sprintf("🧾 Incoming Commits since %s", label), changelogs, logger)
+
+	if changesMarkdown != "" {
+		title := fmt.Sprintf("Incoming commits since %s", label)
+		if err := os.WriteFile(changesMarkdown, internal.RenderChangelogMarkdown(title, changelogs), 0644); err != nil {
+			logger.Error("Failed to write changelog: %v", err)
+			setExitCode(ExitPartialFailure)
+		} else {
+			logger.Success("Changelog written to %s", changesMarkdown)
+		}
+	}
+	if summary.Errors > 0 {
+		setExitCode(ExitPartialFailure)
+	}
+	emitter.Summary(summary)
+}
+
+// resolveChangesSince turns --since into a point in time and a label for the output. A run id
+// starts at that run, and a prefix of run ids ("20240501") at the first run it matches; an
+// empty value starts at the last run.
+func resolveChangesSince(value string, history *internal.History, now time.Time) (time.Time, string, error) {
+	if value == "" {
+		last := history.Runs[len(history.Runs)-1]
+		return internal.HistoryTime(last.StartedAt), fmt.Sprintf("the last run (%s)", formatHistoryTime(last.StartedAt)), nil
+	}
+
+	for _, run := range history.Runs {
+		if strings.HasPrefix(run.RunID, value) {
+			return internal.HistoryTime(run.StartedAt), fmt.Sprintf("run %s", run.RunID), nil
+		}
+	}
+
+	since, err := internal.ParseTimeBound(value, now)
+	if err != nil {
+		return time.Time{}, "", err
+	}
+	return since, since.Format("2006-01-02 15:04:05"), nil
+}
+
+// displayChangelog prints the incoming commits of each repository under a header
+func displayChangelog(title string, changelogs []internal.RepositoryChangelog, logger *internal.Logger) {
+	logger.Header(title)
+	fmt.Println()
+
+	shown := 0
+	for _, changelog := range changelogs {
+		if changelog.Error != "" {
+			color.New(color.FgRed).Printf("   ❌ %s (%s): %s\n", changelog.Project, changelog.Group, changelog.Error)
+			shown++
+			continue
+		}
+		if len(changelog.Commits) == 0 {
+			continue
+		}
+		shown++
+
+		color.New(color.FgCyan, color.Bold).Printf("   %s (%s)", changelog.Project, changelog.Group)
+		commits := fmt.Sprintf("%d commits", len(changelog.Commits))
+		if len(changelog.Commits) == 1 {
+			commits = "1 commit"
+		}
+		color.New(color.FgHiBlack).Printf("  %s → %s, %s\n", shortHash(changelog.From), shortHash(changelog.To), commits)
+		for _, commit := range changelog.Commits {
+			color.New(color.FgYellow).Printf("      %s", shortHash(commit.Hash))
+			color.New(color.FgWhite).Printf("  %s", commit.Subject)
+			color.New(color.FgHiBlack).Printf(" (%s)\n", commit.Author)
+		}
+		fmt.Println()
+	}
+
+	if shown == 0 {
+		color.New(color.FgCyan).Println("   No incoming commits")
+		fmt.Println()
+	}
+}
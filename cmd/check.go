@@ -14,8 +14,11 @@ import (
 )
 
 var (
-	checkParallel int
-	checkGroup    string
+	checkParallel      int
+	checkGroup         string
+	checkFailOnChanges bool
+	checkReportFile    string
+	checkJUnitFile     string
 )
 
 // checkCmd represents the check command
@@ -44,6 +47,9 @@ func init() {
 
 	checkCmd.Flags().IntVarP(&checkParallel, "parallel", "p", 10, "Number of parallel check operations (1-20)")
 	checkCmd.Flags().StringVarP(&checkGroup, "group", "g", "", "Check only repositories from specific group")
+	checkCmd.Flags().BoolVar(&checkFailOnChanges, "fail-on-changes", false, "Exit with code 3 when any repository has uncommitted changes")
+	checkCmd.Flags().StringVar(&checkReportFile, "report", "", "Write an HTML (.html) or Markdown (.md) report to this file")
+	checkCmd.Flags().StringVar(&checkJUnitFile, "junit", "", "Write a JUnit XML report with one test case per repository to this file")
 }
 
 func runCheck(cmd *cobra.Command, args []string) {
@@ -53,11 +59,17 @@ func runCheck(cmd *cobra.Command, args []string) {
 	// Show banner
 	logger.Banner()
 
+	if !validateReportPath(checkReportFile, logger) {
+		return
+	}
+
 	// Load inventory with spinner
 	spinnerLoad := logger.StartSpinner(fmt.Sprintf("Loading inventory from %s", file))
 	inventory, err := internal.LoadInventory(file)
 	if err != nil {
 		logger.StopSpinnerError(spinnerLoad, fmt.Sprintf("Failed to load inventory: %v", err))
+		emitter.Error("Failed to load inventory: %v", err)
+		setExitCode(ExitConfigError)
 		return
 	}
 	logger.StopSpinnerSuccess(spinnerLoad, "Inventory loaded successfully")
@@ -75,6 +87,7 @@ func runCheck(cmd *cobra.Command, args []string) {
 	allProjects := internal.CollectAllProjects(*inventory)
 	if len(allProjects) == 0 {
 		logger.Warning("No projects found in inventory")
+		setExitCode(ExitConfigError)
 		return
 	}
 
@@ -85,6 +98,7 @@ func runCheck(cmd *cobra.Command, args []string) {
 		filteredProjects := internal.FilterProjectsByGroup(allProjects, checkGroup)
 		if len(filteredProjects) == 0 {
 			logger.Warning("No projects found for group: %s", checkGroup)
+			setExitCode(ExitConfigError)
 			return
 		}
 		allProjects = filteredProjects
@@ -95,6 +109,8 @@ func runCheck(cmd *cobra.Command, args []string) {
 	absDir, err := internal.EnsureOutputDirectory(directory, logger)
 	if err != nil {
 		logger.Error("Output directory setup failed: %v", err)
+		emitter.Error("Output directory setup failed: %v", err)
+		setExitCode(ExitConfigError)
 		return
 	}
 
@@ -129,7 +145,9 @@ func runCheck(cmd *cobra.Command, args []string) {
 
 		// Process fallback
 		checkResults := processCheckOperations(existingProjects, logger)
-		displayCheckResults(checkResults, logger, time.Since(startTime).String())
+		summary := displayCheckResults(checkResults, logger, time.Since(startTime).String())
+		writeReport(checkReportFile, checkReport(absDir, checkResults, summary), logger)
+		writeJUnit(checkJUnitFile, checkJUnit(checkResults), startTime, logger)
 		return
 	}
 
@@ -137,21 +155,19 @@ func runCheck(cmd *cobra.Command, args []string) {
 	var existingProjects []internal.ProjectInfo
 	trackedCount := 0
 
+	matches := internal.MatchTrackedProjects(tracker, allProjects)
 	for _, project := range allProjects {
-		// Find project in tracker by URL
-		for _, trackedProject := range tracker.Projects {
-			if trackedProject.URL == project.URL {
-				// Use the tracked local path (actual location)
-				project.LocalPath = trackedProject.LocalPath
-
-				// Verify it still exists
-				if _, err := os.Stat(project.LocalPath); err == nil {
-					if internal.IsGitRepository(project.LocalPath) {
-						existingProjects = append(existingProjects, project)
-						trackedCount++
-					}
+		// Find project in tracker by its stable identity
+		if index, ok := matches[internal.ProjectIdentity(project)]; ok {
+			// Use the tracked local path (actual location)
+			project.LocalPath = tracker.Projects[index].LocalPath
+
+			// Verify it still exists
+			if _, err := os.Stat(project.LocalPath); err == nil {
+				if internal.IsGitRepository(project.LocalPath) {
+					existingProjects = append(existingProjects, project)
+					trackedCount++
 				}
-				break
 			}
 		}
 	}
@@ -166,17 +182,30 @@ func runCheck(cmd *cobra.Command, args []string) {
 
 	// Process check operations
 	checkResults := processCheckOperations(existingProjects, logger)
-	displayCheckResults(checkResults, logger, time.Since(startTime).String())
+	summary := displayCheckResults(checkResults, logger, time.Since(startTime).String())
+	writeReport(checkReportFile, checkReport(absDir, checkResults, summary), logger)
+	writeJUnit(checkJUnitFile, checkJUnit(checkResults), startTime, logger)
 }
 
 type CheckResult struct {
-	Project          internal.ProjectInfo
-	HasChanges       bool
-	ModifiedFiles    int
-	StagedFiles      int
-	UntrackedFiles   int
-	Branch           string
-	Error            string
+	Project        internal.ProjectInfo `json:"project"`
+	HasChanges     bool                 `json:"has_changes"`
+	ModifiedFiles  int                  `json:"modified_files"`
+	StagedFiles    int                  `json:"staged_files"`
+	UntrackedFiles int                  `json:"untracked_files"`
+	Branch         string               `json:"branch"`
+	Error          string               `json:"error,omitempty"`
+}
+
+// ChangesSummary represents the counts shown in the check and scan summaries
+type ChangesSummary struct {
+	Total     int    `json:"total"`
+	Clean     int    `json:"clean"`
+	Modified  int    `json:"modified"`
+	Staged    int    `json:"staged"`
+	Untracked int    `json:"untracked"`
+	Errors    int    `json:"errors"`
+	Duration  string `json:"duration"`
 }
 
 func processCheckOperations(projects []internal.ProjectInfo, logger *internal.Logger) []CheckResult {
@@ -187,8 +216,7 @@ func processCheckOperations(projects []internal.ProjectInfo, logger *internal.Lo
 	var wg sync.WaitGroup
 
 	// Create clean progress bar
-	bar := progressbar.NewOptions(totalProjects,
-		progressbar.OptionSetDescription("🔍 Checking for changes"),
+	bar := internal.NewProgressBar(totalProjects, "🔍 Checking for changes",
 		progressbar.OptionSetWidth(50),
 		progressbar.OptionShowCount(),
 		progressbar.OptionShowIts(),
@@ -219,6 +247,7 @@ func processCheckOperations(projects []internal.ProjectInfo, logger *internal.Lo
 
 		mutex.Lock()
 		results = append(results, result)
+		emitter.Result(result)
 		bar.Add(1)
 		mutex.Unlock()
 	}
@@ -271,7 +300,7 @@ func checkRepositoryChanges(project internal.ProjectInfo) CheckResult {
 	return result
 }
 
-func displayCheckResults(results []CheckResult, logger *internal.Logger, duration string) {
+func displayCheckResults(results []CheckResult, logger *internal.Logger, duration string) ChangesSummary {
 	// Group results
 	var cleanRepos []CheckResult
 	var modifiedRepos []CheckResult
@@ -297,6 +326,18 @@ func displayCheckResults(results []CheckResult, logger *internal.Logger, duratio
 		}
 	}
 
+	summary := ChangesSummary{
+		Total:     len(results),
+		Clean:     len(cleanRepos),
+		Modified:  len(modifiedRepos),
+		Staged:    len(stagedRepos),
+		Untracked: len(untrackedRepos),
+		Errors:    len(errorRepos),
+		Duration:  duration,
+	}
+	emitter.Summary(summary)
+	setExitCodeForChanges(summary, checkFailOnChanges)
+
 	// Display summary
 	logger.Header("📊 Check Results Summary")
 
@@ -381,4 +422,5 @@ func displayCheckResults(results []CheckResult, logger *internal.Logger, duratio
 		fmt.Println()
 		logger.Info("💡 Tip: Review and commit your changes before running sync operations")
 	}
+	return summary
 }
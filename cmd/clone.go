@@ -13,10 +13,17 @@ import (
 )
 
 var (
-	parallel        int
-	groupFilter     string
-	showGroups      bool
-	checkRemote     bool
+	parallel         int
+	groupFilter      string
+	showGroups       bool
+	checkRemote      bool
+	cloneResume      bool
+	cloneRetryFailed bool
+	cloneReportFile  string
+	cloneJUnitFile   string
+	cloneMetricsFile string
+	cloneInteractive bool
+	cloneTUI         bool
 )
 
 // cloneCmd represents the clone command
@@ -46,9 +53,20 @@ func init() {
 	cloneCmd.Flags().StringVarP(&groupFilter, "group", "g", "", "Filter projects by group name")
 	cloneCmd.Flags().BoolVar(&showGroups, "show-groups", false, "Show available groups and exit")
 	cloneCmd.Flags().BoolVar(&checkRemote, "check-remote", false, "Check remote for updates on existing repos (slower)")
+	cloneCmd.Flags().BoolVar(&cloneResume, "resume", false, "Resume an interrupted clone run from its journal")
+	cloneCmd.Flags().BoolVar(&cloneRetryFailed, "retry-failed", false, "Only process projects that failed or were empty in the previous run")
+	cloneCmd.Flags().StringVar(&cloneReportFile, "report", "", "Write an HTML (.html) or Markdown (.md) report to this file")
+	cloneCmd.Flags().StringVar(&cloneJUnitFile, "junit", "", "Write a JUnit XML report with one test case per repository to this file")
+	cloneCmd.Flags().StringVar(&cloneMetricsFile, "metrics-file", "", "Write Prometheus textfile-collector metrics to this file")
+	cloneCmd.Flags().BoolVar(&cloneTUI, "tui", false, "Show a full-screen table of every repository instead of the progress bar")
+	cloneCmd.Flags().BoolVarP(&cloneInteractive, "interactive", "i", false, "Pick repositories and options in the wizard before cloning")
 }
 
 func runClone(cmd *cobra.Command, args []string) {
+	if cloneInteractive && !runWizard("clone") {
+		return
+	}
+
 	logger := internal.NewLogger(verbose)
 	startTime := time.Now()
 
@@ -60,11 +78,22 @@ func runClone(cmd *cobra.Command, args []string) {
 		fmt.Println()
 	}
 
+	if !validateReportPath(cloneReportFile, logger) {
+		return
+	}
+
+	if cloneResume {
+		resumeInterruptedRun("clone", logger)
+		return
+	}
+
 	// Load inventory with spinner
 	spinnerLoad := logger.StartSpinner(fmt.Sprintf("Loading inventory from %s", file))
 	inventory, err := internal.LoadInventory(file)
 	if err != nil {
 		logger.StopSpinnerError(spinnerLoad, fmt.Sprintf("Failed to load inventory: %v", err))
+		emitter.Error("Failed to load inventory: %v", err)
+		setExitCode(ExitConfigError)
 		return
 	}
 	logger.StopSpinnerSuccess(spinnerLoad, "Inventory loaded successfully")
@@ -73,6 +102,7 @@ func runClone(cmd *cobra.Command, args []string) {
 	allProjects := internal.CollectAllProjects(*inventory)
 	if len(allProjects) == 0 {
 		logger.Warning("No projects found in inventory")
+		setExitCode(ExitConfigError)
 		return
 	}
 
@@ -90,6 +120,7 @@ func runClone(cmd *cobra.Command, args []string) {
 		filteredProjects := internal.FilterProjectsByGroup(allProjects, groupFilter)
 		if len(filteredProjects) == 0 {
 			logger.Warning("No projects found for group: %s", groupFilter)
+			setExitCode(ExitConfigError)
 			showAvailableGroups(allProjects, logger)
 			return
 		}
@@ -97,13 +128,29 @@ func runClone(cmd *cobra.Command, args []string) {
 		logger.Info("Filtered to %d projects in group: %s", len(allProjects), groupFilter)
 	}
 
+	// Keep only what was picked in the wizard
+	if wizardSelection != nil {
+		allProjects = applyWizardSelection(allProjects)
+		logger.Info("Using %d projects selected in the wizard", len(allProjects))
+	}
+
 	// Ensure output directory exists and is valid
 	absDir, err := internal.EnsureOutputDirectory(directory, logger)
 	if err != nil {
 		logger.Error("Output directory setup failed: %v", err)
+		emitter.Error("Output directory setup failed: %v", err)
+		setExitCode(ExitConfigError)
 		return
 	}
 
+	// Limit to what failed last time if requested
+	if cloneRetryFailed {
+		var ok bool
+		if allProjects, ok = retryFailedProjects(allProjects, absDir, logger); !ok {
+			return
+		}
+	}
+
 	// Show output directory info if verbose
 	if verbose {
 		internal.ShowOutputDirectoryInfo(absDir, logger)
@@ -118,6 +165,13 @@ func runClone(cmd *cobra.Command, args []string) {
 	color.New(color.FgWhite).Printf("   Parallel: %d\n", parallel)
 	fmt.Println()
 
+	// Move clones whose computed path changed before they are classified
+	relocations, err := internal.RelocateMovedProjects(allProjects, absDir, file, dryRun)
+	if err != nil {
+		logger.Warning("Could not relocate moved projects: %v", err)
+	}
+	displayRelocations(relocations, absDir, logger)
+
 	// Use new smart tracking system with spinner
 	var spinnerMessage string
 	if checkRemote {
@@ -142,8 +196,14 @@ func runClone(cmd *cobra.Command, args []string) {
 	// Clone mode: ONLY clone new projects (no pull)
 	if len(projectsToClone) == 0 {
 		existingCount := len(projectsToPull) + len(projectsUpToDate)
+		summary := internal.Summary{TotalProjects: existingCount, SkippedCount: existingCount, Relocations: relocations}
+		emitter.Summary(summary)
 		logger.Success("✅ No new projects to clone. All %d projects already exist!", existingCount)
 		logger.Info("💡 Use 'syncx pull' to update existing repositories")
+		writeReport(cloneReportFile, summaryReport("clone", absDir, summary), logger)
+		writeJUnit(cloneJUnitFile, summaryJUnit("clone", summary), startTime, logger)
+		writeMetrics(cloneMetricsFile, summaryMetrics(cloneMetricsFile, "clone", absDir, summary), logger)
+		notifyRun("clone", absDir, startTime, summary, nil, logger)
 		return
 	}
 
@@ -154,12 +214,45 @@ func runClone(cmd *cobra.Command, args []string) {
 		logger.Info("📦 Cloning %d new projects", len(projectsToClone))
 	}
 
+	// Resolve per-project clone budgets (inventory overrides, adaptive from tracker)
+	projectsToClone = applyProjectTimeouts(projectsToClone, absDir)
+
+	// Record the plan so an interrupted run can be resumed
+	var journal *internal.RunJournal
+	if !dryRun {
+		journal = internal.NewRunJournal("clone", absDir, file, protocol, parallel, groupFilter, projectsToClone, true)
+		if err := internal.SaveJournal(journal); err != nil {
+			logger.Warning("Could not write run journal: %v", err)
+		} else {
+			resumeHint = true
+		}
+	}
+
 	// Process ONLY new projects (clone only, no pull)
-	summary := processCloneOnly(projectsToClone, logger)
+	summary := processCloneOnly(projectsToClone, journal, logger)
+	summary.Relocations = relocations
 	summary.TotalDuration = time.Since(startTime).String()
+	previous := recordLastRun(absDir, "clone", startTime, summary, logger)
+	recordHistory(absDir, "clone", startTime, summary, logger)
+	recordResultsInTracker(absDir, summary, logger)
+
+	// Repositories skipped in the terminal UI stay in the journal for 'syncx resume'
+	if journal != nil && summary.SkippedCount == 0 {
+		if err := internal.RemoveJournal(absDir); err != nil {
+			logger.Warning("%v", err)
+		}
+	} else if journal != nil {
+		logger.Info("💡 Run 'syncx resume' to process the %d skipped repositories", summary.SkippedCount)
+	}
 
 	// Show summary
 	logger.Summary(summary)
+	emitter.Summary(summary)
+	setExitCodeForSummary(summary)
+	writeReport(cloneReportFile, summaryReport("clone", absDir, summary), logger)
+	writeJUnit(cloneJUnitFile, summaryJUnit("clone", summary), startTime, logger)
+	writeMetrics(cloneMetricsFile, summaryMetrics(cloneMetricsFile, "clone", absDir, summary), logger)
+	notifyRun("clone", absDir, startTime, summary, previous, logger)
 
 	if dryRun {
 		color.New(color.FgYellow, color.Bold).Println("\n💡 This was a dry run. Run without --dry-run to execute operations.")
@@ -167,74 +260,88 @@ func runClone(cmd *cobra.Command, args []string) {
 }
 
 
-func processCloneOnly(projectsToClone []internal.ProjectInfo, logger *internal.Logger) internal.Summary {
+// cloneOperation clones one project, or only describes the clone in dry-run mode
+func cloneOperation(project internal.ProjectInfo) internal.OperationResult {
+	if dryRun {
+		return internal.OperationResult{
+			Success:  true,
+			Project:  project,
+			Message:  fmt.Sprintf("DRY RUN: Would clone %s", project.Name),
+			IsClone:  true,
+			Duration: "0s",
+		}
+	}
+	result := internal.CloneRepositorySilent(project.GitURL, project.LocalPath, project.CloneTimeout)
+	result.Project = project
+	if result.Success {
+		result.AfterCommit, _ = internal.GetCurrentCommitHash(project.LocalPath)
+	}
+	return result
+}
+
+func processCloneOnly(projectsToClone []internal.ProjectInfo, journal *internal.RunJournal, logger *internal.Logger) internal.Summary {
 	totalProjects := len(projectsToClone)
 
 	var results []internal.OperationResult
 	var mutex sync.Mutex
 	var wg sync.WaitGroup
 
-	// Create clean progress bar with proper single-line rendering
-	bar := progressbar.NewOptions(totalProjects,
-		progressbar.OptionSetDescription("📥 Cloning new repositories"),
-		progressbar.OptionSetWidth(50),
-		progressbar.OptionShowCount(),
-		progressbar.OptionShowIts(),
-		progressbar.OptionSetItsString("repos"),
-		progressbar.OptionThrottle(65*time.Millisecond),
-		progressbar.OptionShowElapsedTimeOnFinish(),
-		progressbar.OptionSetTheme(progressbar.Theme{
-			Saucer:        "█",
-			SaucerHead:    "█",
-			SaucerPadding: "░",
-			BarStart:      "[",
-			BarEnd:        "]",
-		}),
-		progressbar.OptionSetRenderBlankState(true),
-		progressbar.OptionClearOnFinish(),
-		progressbar.OptionUseANSICodes(true), // Force ANSI codes for proper single-line updates
-	)
-
-	// Create semaphore for parallel processing
-	semaphore := make(chan struct{}, parallel)
-
-	// Process function for cloning only
-	processProject := func(project internal.ProjectInfo) {
-		defer wg.Done()
-		semaphore <- struct{}{}
-		defer func() { <-semaphore }()
-
-		var result internal.OperationResult
-		if dryRun {
-			result = internal.OperationResult{
-				Success:  true,
-				Project:  project,
-				Message:  fmt.Sprintf("DRY RUN: Would clone %s", project.Name),
-				IsClone:  true,
-				Duration: "0s",
-			}
-		} else {
-			result = internal.CloneRepositorySilent(project.GitURL, project.LocalPath)
-			result.Project = project
+	skipped := 0
+	if tuiResults, tuiSkipped, ok := runWithTUI(cloneTUI, "📥 Cloning new repositories", projectsToClone, parallel, cloneOperation, journal, logger); ok {
+		results, skipped = tuiResults, tuiSkipped
+	} else {
+		// Create clean progress bar with proper single-line rendering
+		bar := internal.NewProgressBar(totalProjects, "📥 Cloning new repositories",
+			progressbar.OptionSetWidth(50),
+			progressbar.OptionShowCount(),
+			progressbar.OptionShowIts(),
+			progressbar.OptionSetItsString("repos"),
+			progressbar.OptionThrottle(65*time.Millisecond),
+			progressbar.OptionShowElapsedTimeOnFinish(),
+			progressbar.OptionSetTheme(progressbar.Theme{
+				Saucer:        "█",
+				SaucerHead:    "█",
+				SaucerPadding: "░",
+				BarStart:      "[",
+				BarEnd:        "]",
+			}),
+			progressbar.OptionSetRenderBlankState(true),
+			progressbar.OptionClearOnFinish(),
+			progressbar.OptionUseANSICodes(true), // Force ANSI codes for proper single-line updates
+		)
+
+		// Create semaphore for parallel processing
+		semaphore := make(chan struct{}, parallel)
+
+		// Process function for cloning only
+		processProject := func(project internal.ProjectInfo) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			result := cloneOperation(project)
+			internal.MarkJournalJob(journal, result)
+
+			mutex.Lock()
+			results = append(results, result)
+			emitter.Result(result)
+			internal.LogOperationResult(result)
+			bar.Add(1)
+			mutex.Unlock()
 		}
 
-		mutex.Lock()
-		results = append(results, result)
-		bar.Add(1)
-		mutex.Unlock()
-	}
+		// Start clone operations
+		for _, project := range projectsToClone {
+			wg.Add(1)
+			go processProject(project)
+		}
 
-	// Start clone operations
-	for _, project := range projectsToClone {
-		wg.Add(1)
-		go processProject(project)
+		// Wait for all operations to complete
+		wg.Wait()
+		bar.Finish()
+		fmt.Println()
 	}
 
-	// Wait for all operations to complete
-	wg.Wait()
-	bar.Finish()
-	fmt.Println()
-
 	// Show detailed results after progress bar completes
 	logger.Header("📊 Clone Results")
 
@@ -253,7 +360,7 @@ func processCloneOnly(projectsToClone []internal.ProjectInfo, logger *internal.L
 	if len(successfulOps) > 0 {
 		color.New(color.FgGreen, color.Bold).Printf("✅ Successfully Cloned (%d):\n", len(successfulOps))
 		for _, result := range successfulOps {
-			color.New(color.FgGreen).Printf("   %s (%s)\n", result.Project.Name, result.Duration)
+			color.New(color.FgGreen).Printf("   %s (%s%s)\n", result.Project.Name, result.Duration, attemptsNote(result))
 		}
 		fmt.Println()
 	}
@@ -279,6 +386,7 @@ func processCloneOnly(projectsToClone []internal.ProjectInfo, logger *internal.L
 	// Calculate summary
 	summary := internal.Summary{
 		TotalProjects: totalProjects,
+		SkippedCount:  skipped,
 	}
 
 	var failedProjects []internal.ProjectInfo
@@ -287,6 +395,10 @@ func processCloneOnly(projectsToClone []internal.ProjectInfo, logger *internal.L
 	for _, result := range results {
 		if result.Success {
 			summary.SuccessCount++
+			if result.Attempts > 1 {
+				summary.RetriedCount++
+				summary.RetriedProjects = append(summary.RetriedProjects, result.Project)
+			}
 			summary.ClonedCount++
 		} else if result.IsEmpty {
 			summary.EmptyCount++
@@ -299,6 +411,7 @@ func processCloneOnly(projectsToClone []internal.ProjectInfo, logger *internal.L
 
 	summary.FailedProjects = failedProjects
 	summary.EmptyProjects = emptyProjects
+	summary.Results = results
 	return summary
 }
 
@@ -310,8 +423,7 @@ func processProjects(projectsToClone, projectsToPull []internal.ProjectInfo, log
 	var wg sync.WaitGroup
 
 	// Create clean progress bar with better formatting
-	bar := progressbar.NewOptions(totalProjects,
-		progressbar.OptionSetDescription("🚀 Processing repositories"),
+	bar := internal.NewProgressBar(totalProjects, "🚀 Processing repositories",
 		progressbar.OptionSetWidth(50),
 		progressbar.OptionShowCount(),
 		progressbar.OptionShowIts(),
@@ -345,6 +457,8 @@ func processProjects(projectsToClone, projectsToPull []internal.ProjectInfo, log
 		
 		mutex.Lock()
 		results = append(results, result)
+		emitter.Result(result)
+		internal.LogOperationResult(result)
 		// Update progress bar with current project info
 		bar.Describe(fmt.Sprintf("🚀 Processing: %s", project.Name))
 		bar.Add(1)
@@ -392,7 +506,7 @@ func processProjects(projectsToClone, projectsToPull []internal.ProjectInfo, log
 			if result.IsClone {
 				action = "Cloned"
 			}
-			color.New(color.FgGreen).Printf("   %s %s (%s)\n", action, result.Project.Name, result.Duration)
+			color.New(color.FgGreen).Printf("   %s %s (%s%s)\n", action, result.Project.Name, result.Duration, attemptsNote(result))
 		}
 		fmt.Println()
 	}
@@ -426,6 +540,10 @@ func processProjects(projectsToClone, projectsToPull []internal.ProjectInfo, log
 	for _, result := range results {
 		if result.Success {
 			summary.SuccessCount++
+			if result.Attempts > 1 {
+				summary.RetriedCount++
+				summary.RetriedProjects = append(summary.RetriedProjects, result.Project)
+			}
 			if result.IsClone {
 				summary.ClonedCount++
 			} else {
@@ -442,6 +560,7 @@ func processProjects(projectsToClone, projectsToPull []internal.ProjectInfo, log
 
 	summary.FailedProjects = failedProjects
 	summary.EmptyProjects = emptyProjects
+	summary.Results = results
 	return summary
 }
 
@@ -458,4 +577,35 @@ func showAvailableGroups(projects []internal.ProjectInfo, logger *internal.Logge
 	
 	fmt.Println()
 	color.New(color.FgYellow).Println("💡 Use --group <group-name> to filter by a specific group")
-}
\ No newline at end of file
+}
+
+// displayRelocations reports the clones that were (or would be) moved to their new path
+func displayRelocations(relocations []internal.Relocation, absDir string, logger *internal.Logger) {
+	if len(relocations) == 0 {
+		return
+	}
+
+	logger.Header("🚚 Relocated Projects")
+	fmt.Println()
+	for _, relocation := range relocations {
+		from, to := relativeTo(absDir, relocation.From), relativeTo(absDir, relocation.To)
+		switch relocation.Status {
+		case internal.RelocationMoved:
+			color.New(color.FgGreen).Printf("   ✅ %s: %s → %s\n", relocation.Project.Name, from, to)
+		case internal.RelocationWouldMove:
+			logger.DryRun("Would move %s: %s → %s", relocation.Project.Name, from, to)
+		case internal.RelocationAlreadyMoved:
+			if dryRun {
+				logger.DryRun("Would point the tracker at %s for %s (already moved)", to, relocation.Project.Name)
+			} else {
+				color.New(color.FgGreen).Printf("   ✅ %s: already at %s, tracker updated\n", relocation.Project.Name, to)
+			}
+		case internal.RelocationCollision:
+			color.New(color.FgYellow).Printf("   ⚠️  %s: kept at %s, %s (%s)\n", relocation.Project.Name, from, relocation.Detail, to)
+		default:
+			setExitCode(ExitPartialFailure)
+			color.New(color.FgRed).Printf("   ❌ %s: could not move %s → %s: %s\n", relocation.Project.Name, from, to, relocation.Detail)
+		}
+	}
+	fmt.Println()
+}
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+
+	"olive-clone-assistant-v2/internal"
+
+	"github.com/fatih/color"
+)
+
+// Process exit codes (documented in README.md)
+const (
+	ExitSuccess        = 0   // Everything succeeded
+	ExitPartialFailure = 1   // Some repositories failed (or a status --fail-on condition other than dirty matched)
+	ExitConfigError    = 2   // Invalid flags, config file, inventory or output directory
+	ExitDirtyRepos     = 3   // Uncommitted changes found (check/scan --fail-on-changes, status --fail-on dirty)
+	ExitInterrupted    = 130 // Interrupted by Ctrl+C or SIGTERM
+)
+
+var (
+	exitCode = ExitSuccess
+
+	// resumeHint is set once a run journal exists, so an interruption can point to 'syncx resume'
+	resumeHint bool
+)
+
+// setExitCode records an exit code, keeping the most severe one
+func setExitCode(code int) {
+	if code > exitCode {
+		exitCode = code
+	}
+}
+
+// setExitCodeForSummary flags a partial failure when any repository failed
+func setExitCodeForSummary(summary internal.Summary) {
+	if summary.FailureCount > 0 {
+		setExitCode(ExitPartialFailure)
+	}
+}
+
+// setExitCodeForChanges flags errors as a partial failure and, when requested, dirty repositories
+func setExitCodeForChanges(summary ChangesSummary, failOnChanges bool) {
+	if summary.Errors > 0 {
+		setExitCode(ExitPartialFailure)
+	}
+	if failOnChanges && summary.Total-summary.Clean-summary.Errors > 0 {
+		setExitCode(ExitDirtyRepos)
+	}
+}
+
+// ExitCode returns the exit code the process should terminate with
+func ExitCode() int {
+	return exitCode
+}
+
+// exitWithConfigError terminates immediately for errors found before a command runs
+func exitWithConfigError() {
+	emitter.Flush()
+	os.Exit(ExitConfigError)
+}
+
+// restoreTerminal leaves full-screen mode before the interrupt message is printed (set by the terminal UI)
+var restoreTerminal func()
+
+// handleInterrupts exits with ExitInterrupted on Ctrl+C or SIGTERM
+// Progress is already persisted (journal, tracker), so there is nothing else to undo
+func handleInterrupts() {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		<-signals
+		exitInterrupted()
+	}()
+}
+
+// exitInterrupted restores the terminal, reports the interruption and exits with ExitInterrupted
+func exitInterrupted() {
+	if restoreTerminal != nil {
+		restoreTerminal()
+	}
+	color.New(color.FgYellow, color.Bold).Println("\n⚠️  Interrupted")
+	if resumeHint {
+		color.New(color.FgYellow).Println("💡 Run 'syncx resume' to continue where this run stopped")
+	}
+	emitter.Error("interrupted")
+	internal.FileLog().Warn("run interrupted", "exit_code", ExitInterrupted)
+	internal.CloseLogFile()
+	emitter.Flush()
+	os.Exit(ExitInterrupted)
+}
@@ -0,0 +1,278 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"olive-clone-assistant-v2/internal"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	historySince string
+	historyUntil string
+	historyLimit int
+)
+
+// historyCmd shows the recorded runs, or the timeline of one project
+var historyCmd = &cobra.Command{
+	Use:   "history [project]",
+	Short: "📜 Show past runs, or the timeline of one repository",
+	Long: color.New(color.FgBlue, color.Bold).Sprint(`
+📜 History Command
+==================
+
+Every clone, pull, resume and ui run is appended to the history of the output
+directory (.olive-clone-history.jsonl), with each repository's commit before
+and after, its outcome and its duration.
+
+• Without a project: the runs, their duration and what they changed
+• With a project name: its timeline and when it last changed
+• --since/--until take a duration (24h, 7d, 2w), a date or a timestamp
+`),
+	Args: cobra.MaximumNArgs(1),
+	Run:  runHistory,
+}
+
+func init() {
+	rootCmd.AddCommand(historyCmd)
+
+	historyCmd.Flags().StringVar(&historySince, "since", "", "Only show records from this time on (e.g. 24h, 7d, 2024-05-01)")
+	historyCmd.Flags().StringVar(&historyUntil, "until", "", "Only show records up to this time")
+	historyCmd.Flags().IntVarP(&historyLimit, "limit", "n", 20, "Maximum number of records to show, most recent first (0 for all)")
+}
+
+// HistorySummary is the structured output of the history command
+type HistorySummary struct {
+	Runs           int     `json:"runs"`
+	Entries        int     `json:"entries"`
+	AverageSeconds float64 `json:"average_duration_seconds,omitempty"`
+	LongestSeconds float64 `json:"longest_duration_seconds,omitempty"`
+	LastChanged    string  `json:"last_changed,omitempty"`
+}
+
+func runHistory(cmd *cobra.Command, args []string) {
+	logger := internal.NewLogger(verbose)
+
+	absDir, err := filepath.Abs(directory)
+	if err != nil {
+		logger.Error("Failed to get absolute path for %s: %v", directory, err)
+		setExitCode(ExitConfigError)
+		return
+	}
+
+	now := time.Now()
+	since, err := internal.ParseTimeBound(historySince, now)
+	until := now
+	if err == nil && historyUntil != "" {
+		until, err = internal.ParseTimeBound(historyUntil, now)
+	}
+	if err != nil {
+		logger.Error("%v", err)
+		emitter.Error("%v", err)
+		setExitCode(ExitConfigError)
+		return
+	}
+	inRange := func(value string) bool {
+		t := internal.HistoryTime(value)
+		return !t.Before(since) && !t.After(until)
+	}
+
+	history, err := internal.LoadHistory(absDir)
+	if err != nil {
+		logger.Error("%v", err)
+		emitter.Error("%v", err)
+		setExitCode(ExitConfigError)
+		return
+	}
+	if history.Corrupt > 0 {
+		logger.Warning("Skipped %d unreadable history lines", history.Corrupt)
+	}
+	if len(history.Runs) == 0 {
+		logger.Warning("No runs recorded in %s yet", absDir)
+		emitter.Summary(HistorySummary{})
+		return
+	}
+
+	if len(args) == 1 {
+		showProjectHistory(history, args[0], inRange, logger)
+		return
+	}
+
+	var runs []internal.HistoryRun
+	for _, run := range history.Runs {
+		if inRange(run.StartedAt) {
+			runs = append(runs, run)
+		}
+	}
+	summary := HistorySummary{Runs: len(runs)}
+	for _, run := range runs {
+		summary.AverageSeconds += run.Seconds
+		if run.Seconds > summary.LongestSeconds {
+			summary.LongestSeconds = run.Seconds
+		}
+	}
+	if len(runs) > 0 {
+		summary.AverageSeconds /= float64(len(runs))
+	}
+
+	logger.Header("📜 Sync History")
+	fmt.Println()
+	shown := mostRecent(len(runs), historyLimit)
+	for i := len(runs) - 1; i >= len(runs)-shown; i-- {
+		run := runs[i]
+		emitter.Result(run)
+
+		changes := []string{}
+		for _, part := range []struct {
+			count int
+			label string
+		}{{run.Cloned, "cloned"}, {run.Updated, "updated"}, {run.Unchanged, "unchanged"}, {run.Empty, "empty"}, {run.Failed, "failed"}, {run.Skipped, "skipped"}} {
+			if part.count > 0 {
+				changes = append(changes, fmt.Sprintf("%d %s", part.count, part.label))
+			}
+		}
+		if len(changes) == 0 {
+			changes = append(changes, "nothing to do")
+		}
+
+		line := color.New(color.FgGreen)
+		if run.Failed > 0 {
+			line = color.New(color.FgRed)
+		}
+		line.Printf("   %s  %-6s %8s  %s\n", formatHistoryTime(run.StartedAt), run.Command, formatSeconds(run.Seconds), strings.Join(changes, ", "))
+	}
+
+	fmt.Println()
+	if len(runs) == 0 {
+		logger.Warning("No runs in the selected time range")
+	} else {
+		color.New(color.FgCyan).Printf("   %d runs · average %s · longest %s\n", len(runs), formatSeconds(summary.AverageSeconds), formatSeconds(summary.LongestSeconds))
+		if shown < len(runs) {
+			color.New(color.FgHiBlack).Printf("   💡 Showing the last %d, use --limit 0 to see all\n", shown)
+		}
+	}
+	emitter.Summary(summary)
+}
+
+// showProjectHistory prints the timeline of the projects matching name
+func showProjectHistory(history *internal.History, name string, inRange func(string) bool, logger *internal.Logger) {
+	var entries []internal.HistoryEntry
+	known := false
+	for _, entry := range history.Entries {
+		if !matchesHistoryProject(entry, name) {
+			continue
+		}
+		known = true
+		if inRange(entry.Time) {
+			entries = append(entries, entry)
+		}
+	}
+	if !known {
+		logger.Warning("No history for project %s", name)
+		setExitCode(ExitConfigError)
+		emitter.Summary(HistorySummary{})
+		return
+	}
+
+	summary := HistorySummary{Entries: len(entries)}
+	for i := len(history.Entries) - 1; i >= 0; i-- {
+		entry := history.Entries[i]
+		if matchesHistoryProject(entry, name) && (entry.Outcome == internal.HistoryCloned || entry.Outcome == internal.HistoryUpdated) {
+			summary.LastChanged = entry.Time
+			break
+		}
+	}
+
+	logger.Header(fmt.Sprintf("📜 History of %s", name))
+	fmt.Println()
+	shown := mostRecent(len(entries), historyLimit)
+	for i := len(entries) - 1; i >= len(entries)-shown; i-- {
+		entry := entries[i]
+		emitter.Result(entry)
+
+		commits := ""
+		switch entry.Outcome {
+		case internal.HistoryCloned:
+			commits = "at " + shortHash(entry.After)
+		case internal.HistoryUpdated:
+			commits = shortHash(entry.Before) + " → " + shortHash(entry.After)
+		case internal.HistoryUnchanged:
+			commits = "at " + shortHash(entry.After)
+		case internal.HistoryFailed:
+			commits = entry.Message
+		}
+
+		line := color.New(color.FgWhite)
+		switch entry.Outcome {
+		case internal.HistoryCloned, internal.HistoryUpdated:
+			line = color.New(color.FgGreen)
+		case internal.HistoryFailed:
+			line = color.New(color.FgRed)
+		case internal.HistoryEmpty:
+			line = color.New(color.FgYellow)
+		}
+		line.Printf("   %s  %-6s %-9s %8s  %s\n", formatHistoryTime(entry.Time), entry.Command, entry.Outcome, formatSeconds(entry.Seconds), commits)
+	}
+
+	fmt.Println()
+	if len(entries) == 0 {
+		logger.Warning("No records in the selected time range")
+	}
+	if summary.LastChanged != "" {
+		color.New(color.FgCyan).Printf("   Last changed: %s\n", formatHistoryTime(summary.LastChanged))
+	} else {
+		color.New(color.FgCyan).Println("   No changes recorded")
+	}
+	if shown < len(entries) {
+		color.New(color.FgHiBlack).Printf("   💡 Showing the last %d, use --limit 0 to see all\n", shown)
+	}
+	emitter.Summary(summary)
+}
+
+// matchesHistoryProject matches a project by name, or by identity ("gitlab.com/team/app") or its tail
+func matchesHistoryProject(entry internal.HistoryEntry, name string) bool {
+	return strings.EqualFold(entry.Project, name) || entry.ID == name || strings.HasSuffix(entry.ID, "/"+name)
+}
+
+// mostRecent is how many of count records a limit lets through (0 means no limit)
+func mostRecent(count, limit int) int {
+	if limit > 0 && limit < count {
+		return limit
+	}
+	return count
+}
+
+// formatHistoryTime shows a history timestamp in local time
+func formatHistoryTime(value string) string {
+	t := internal.HistoryTime(value)
+	if t.IsZero() {
+		return value
+	}
+	return t.Local().Format("2006-01-02 15:04:05")
+}
+
+// formatSeconds shows a duration in seconds the way Go prints durations
+func formatSeconds(seconds float64) string {
+	d := time.Duration(seconds * float64(time.Second))
+	if d >= time.Second {
+		d = d.Round(100 * time.Millisecond)
+	} else {
+		d = d.Round(time.Millisecond)
+	}
+	return d.String()
+}
+
+// recordHistory appends the finished run to the history of the output directory
+func recordHistory(absDir, command string, startTime time.Time, summary internal.Summary, logger *internal.Logger) {
+	if dryRun {
+		return
+	}
+	if _, err := internal.AppendHistory(absDir, command, startTime, summary); err != nil {
+		logger.Warning("Could not record run history: %v", err)
+	}
+}
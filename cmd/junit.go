@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"olive-clone-assistant-v2/internal"
+)
+
+// writeJUnit writes the JUnit XML file if --junit was given
+func writeJUnit(path string, suite *internal.JUnitTestSuite, startTime time.Time, logger *internal.Logger) {
+	if path == "" {
+		return
+	}
+	if err := internal.WriteJUnit(path, suite, time.Since(startTime)); err != nil {
+		logger.Error("%v", err)
+		setExitCode(ExitPartialFailure)
+		return
+	}
+	logger.Success("JUnit report written to %s", path)
+}
+
+// summaryJUnit builds the test cases of a clone or pull run
+func summaryJUnit(command string, summary internal.Summary) *internal.JUnitTestSuite {
+	suite := internal.NewJUnitTestSuite("syncx." + command)
+	suite.AddSummary(summary)
+	return suite
+}
+
+// statusJUnit builds one test case per repository of a status run
+// Dirty and behind repositories are failures or skipped depending on the config file
+func statusJUnit(statuses []RepoStatus) *internal.JUnitTestSuite {
+	suite := internal.NewJUnitTestSuite("syncx.status")
+
+	for _, status := range statuses {
+		className := internal.JUnitClassName(status.Project.Group)
+		switch {
+		case !status.Exists:
+			suite.AddCase(status.Project.Name, className, 0, internal.JUnitFailure, "missing",
+				fmt.Sprintf("Repository not found at %s", status.Project.LocalPath))
+		case !status.IsGitRepo:
+			suite.AddCase(status.Project.Name, className, 0, internal.JUnitFailure, "not-git-repo",
+				fmt.Sprintf("%s is not a git repository", status.Project.LocalPath))
+		case status.IsEmpty:
+			suite.AddCase(status.Project.Name, className, 0, internal.JUnitSkipped, "", "Repository is empty (no commits)")
+		default:
+			var problems []string
+			outcome := internal.JUnitPassed
+			if !status.IsClean {
+				problems = append(problems, fmt.Sprintf("%d uncommitted files on %s", status.Uncommitted, status.Branch))
+				outcome = worseJUnitOutcome(outcome, config.JUnit.DirtyOutcome())
+			}
+			if status.Behind > 0 {
+				problems = append(problems, fmt.Sprintf("%d commits behind remote on %s", status.Behind, status.Branch))
+				outcome = worseJUnitOutcome(outcome, config.JUnit.BehindOutcome())
+			}
+			if status.Operation != "" {
+				problems = append(problems, fmt.Sprintf("%s in progress", status.Operation))
+				outcome = worseJUnitOutcome(outcome, config.JUnit.DirtyOutcome())
+			}
+			if status.Stashes > 0 {
+				problems = append(problems, fmt.Sprintf("%d stashes", status.Stashes))
+				outcome = worseJUnitOutcome(outcome, config.JUnit.DirtyOutcome())
+			}
+			for _, branch := range status.UnpushedBranches {
+				problems = append(problems, fmt.Sprintf("branch %s has %d unpushed commits", branch.Name, branch.Ahead))
+				outcome = worseJUnitOutcome(outcome, config.JUnit.DirtyOutcome())
+			}
+			if status.Detached {
+				problems = append(problems, fmt.Sprintf("detached HEAD at %s", status.Commit))
+				outcome = worseJUnitOutcome(outcome, config.JUnit.DirtyOutcome())
+			}
+			if status.WrongBranch {
+				problems = append(problems, fmt.Sprintf("on %s instead of %s", status.Branch, status.ExpectedBranch))
+				outcome = worseJUnitOutcome(outcome, config.JUnit.DirtyOutcome())
+			}
+			message := strings.Join(problems, "; ")
+			if outcome == internal.JUnitPassed {
+				message = fmt.Sprintf("Clean on %s", status.Branch)
+			}
+			suite.AddCase(status.Project.Name, className, 0, outcome, "needs-attention", message)
+		}
+	}
+
+	return suite
+}
+
+// checkJUnit builds one test case per repository of a check run
+func checkJUnit(results []CheckResult) *internal.JUnitTestSuite {
+	suite := internal.NewJUnitTestSuite("syncx.check")
+	for _, result := range results {
+		addChangesCase(suite, result.Project.Name, internal.JUnitClassName(result.Project.Group), result.Branch,
+			result.Error, result.HasChanges, result.ModifiedFiles, result.StagedFiles, result.UntrackedFiles)
+	}
+	return suite
+}
+
+// scanJUnit builds one test case per repository of a scan run
+func scanJUnit(results []ScanResult) *internal.JUnitTestSuite {
+	suite := internal.NewJUnitTestSuite("syncx.scan")
+	for _, result := range results {
+		addChangesCase(suite, result.Path, "syncx.scan", result.Branch,
+			result.Error, result.HasChanges, result.ModifiedFiles, result.StagedFiles, result.UntrackedFiles)
+	}
+	return suite
+}
+
+// addChangesCase adds a check/scan result: errors fail, changes use the configured dirty outcome
+func addChangesCase(suite *internal.JUnitTestSuite, name, className, branch, checkError string, hasChanges bool, modified, staged, untracked int) {
+	switch {
+	case checkError != "":
+		suite.AddCase(name, className, 0, internal.JUnitFailure, "error", checkError)
+	case hasChanges:
+		message := fmt.Sprintf("Uncommitted changes on %s: %d modified, %d staged, %d untracked", branch, modified, staged, untracked)
+		suite.AddCase(name, className, 0, config.JUnit.DirtyOutcome(), "dirty", message)
+	default:
+		suite.AddCase(name, className, 0, internal.JUnitPassed, "", fmt.Sprintf("Clean on %s", branch))
+	}
+}
+
+// worseJUnitOutcome returns the more severe of two outcomes (failure > skipped > passed)
+func worseJUnitOutcome(a, b string) string {
+	rank := map[string]int{internal.JUnitPassed: 0, internal.JUnitSkipped: 1, internal.JUnitFailure: 2}
+	if rank[b] > rank[a] {
+		return b
+	}
+	return a
+}
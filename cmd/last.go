@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"olive-clone-assistant-v2/internal"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// lastCmd represents the last command
+var lastCmd = &cobra.Command{
+	Use:   "last",
+	Short: "🕘 Show the summary of the previous clone or pull run",
+	Long: color.New(color.FgBlue, color.Bold).Sprint(`
+🕘 Last Run
+===========
+
+Print the summary of the most recent clone, pull or resume run again.
+Results are stored next to the tracker in the output directory, including
+the error message and duration of every repository.
+
+Use 'clone --retry-failed' or 'pull --retry-failed' to rerun only what failed.
+`),
+	Run: runLast,
+}
+
+func init() {
+	rootCmd.AddCommand(lastCmd)
+}
+
+func runLast(cmd *cobra.Command, args []string) {
+	logger := internal.NewLogger(verbose)
+
+	absDir, err := filepath.Abs(directory)
+	if err != nil {
+		logger.Error("Failed to get absolute path for %s: %v", directory, err)
+		setExitCode(ExitConfigError)
+		return
+	}
+
+	lastRun, err := internal.LoadLastRun(absDir)
+	if err != nil {
+		logger.Error("%v", err)
+		setExitCode(ExitConfigError)
+		return
+	}
+	if lastRun == nil {
+		logger.Warning("No previous run recorded in %s", absDir)
+		return
+	}
+
+	for _, result := range lastRun.Summary.Results {
+		emitter.Result(result)
+	}
+	emitter.Summary(lastRun)
+
+	logger.Header("🕘 Last Run")
+	color.New(color.FgCyan).Printf("   Command: %s\n", lastRun.Command)
+	color.New(color.FgCyan).Printf("   Started: %s\n", lastRun.StartedAt)
+	color.New(color.FgCyan).Printf("   Finished: %s\n", lastRun.FinishedAt)
+	color.New(color.FgCyan).Printf("   Output Directory: %s\n", lastRun.OutputDirectory)
+	color.New(color.FgCyan).Printf("   Inventory: %s\n", lastRun.InventoryFile)
+
+	// Show what went wrong for each failed repository
+	var failed []internal.OperationResult
+	for _, result := range lastRun.Summary.Results {
+		if !result.Success && !result.IsEmpty {
+			failed = append(failed, result)
+		}
+	}
+	if len(failed) > 0 {
+		fmt.Println()
+		color.New(color.FgRed, color.Bold).Printf("❌ Failed Operations (%d):\n", len(failed))
+		for _, result := range failed {
+			color.New(color.FgRed).Printf("   %s: %s (%s)\n", result.Project.Name, result.Message, result.Duration)
+		}
+	}
+
+	logger.Summary(lastRun.Summary)
+
+	if len(lastRun.Summary.FailedProjects) > 0 || len(lastRun.Summary.EmptyProjects) > 0 {
+		fmt.Println()
+		logger.Info("💡 Use '%s --retry-failed' to rerun only these repositories", lastRun.Command)
+	}
+}
+
+// retryFailedProjects limits projects to those that failed or were empty in the previous run
+// Returns false when there is nothing to retry
+func retryFailedProjects(projects []internal.ProjectInfo, absDir string, logger *internal.Logger) ([]internal.ProjectInfo, bool) {
+	lastRun, err := internal.LoadLastRun(absDir)
+	if err != nil {
+		logger.Error("%v", err)
+		setExitCode(ExitConfigError)
+		return nil, false
+	}
+	if lastRun == nil {
+		logger.Warning("No previous run recorded in %s, nothing to retry", absDir)
+		return nil, false
+	}
+
+	filtered := internal.FilterProjectsToRetry(projects, lastRun)
+	if len(filtered) == 0 {
+		logger.Success("Nothing failed in the previous %s run (%s)", lastRun.Command, lastRun.FinishedAt)
+		return nil, false
+	}
+
+	logger.Success("Retrying %d projects that failed or were empty in the previous %s run", len(filtered), lastRun.Command)
+	return filtered, true
+}
+
+// recordLastRun stores the run results so they can be shown again or retried
+// It returns the run it replaces, so notifications can report what changed
+func recordLastRun(absDir, command string, startTime time.Time, summary internal.Summary, logger *internal.Logger) *internal.LastRun {
+	if dryRun {
+		return nil
+	}
+	previous, _ := internal.LoadLastRun(absDir)
+	if err := internal.SaveLastRun(absDir, command, file, startTime, summary); err != nil {
+		logger.Warning("Could not record run results: %v", err)
+	}
+	return previous
+}
@@ -53,6 +53,8 @@ func runList(cmd *cobra.Command, args []string) {
 	inventory, err := internal.LoadInventory(file)
 	if err != nil {
 		logger.Error("Failed to load inventory: %v", err)
+		emitter.Error("Failed to load inventory: %v", err)
+		setExitCode(ExitConfigError)
 		return
 	}
 
@@ -60,6 +62,7 @@ func runList(cmd *cobra.Command, args []string) {
 	allProjects := internal.CollectAllProjects(*inventory)
 	if len(allProjects) == 0 {
 		logger.Warning("No projects found in inventory")
+		setExitCode(ExitConfigError)
 		return
 	}
 
@@ -76,6 +79,15 @@ func runList(cmd *cobra.Command, args []string) {
 	}
 	sort.Strings(groups)
 
+	// Emit structured output
+	if emitter.Structured() {
+		for _, project := range allProjects {
+			project.GitURL = internal.FormatGitURL(project.URL, protocol)
+			emitter.Result(project)
+		}
+		emitter.Summary(ListSummary{TotalProjects: len(allProjects), TotalGroups: len(groups), Groups: groups})
+	}
+
 	// Display summary
 	logger.Header(fmt.Sprintf("📊 Inventory Summary (%s)", file))
 	color.New(color.FgCyan, color.Bold).Printf("Total Projects: %d\n", len(allProjects))
@@ -91,6 +103,13 @@ func runList(cmd *cobra.Command, args []string) {
 	}
 }
 
+// ListSummary represents the inventory summary shown by list
+type ListSummary struct {
+	TotalProjects int      `json:"total_projects"`
+	TotalGroups   int      `json:"total_groups"`
+	Groups        []string `json:"groups"`
+}
+
 func showGroupsOnly(groups []string, groupMap map[string][]internal.ProjectInfo, logger *internal.Logger) {
 	logger.Header("📁 Groups")
 	
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"time"
+
+	"olive-clone-assistant-v2/internal"
+)
+
+// writeMetrics writes the Prometheus textfile if --metrics-file was given
+func writeMetrics(path string, metrics *internal.Metrics, logger *internal.Logger) {
+	if path == "" {
+		return
+	}
+	if err := internal.WriteMetrics(path, metrics); err != nil {
+		logger.Error("%v", err)
+		setExitCode(ExitPartialFailure)
+		return
+	}
+	logger.Success("Metrics written to %s", path)
+}
+
+// summaryMetrics builds the metrics of a clone or pull run
+func summaryMetrics(path, command, absDir string, summary internal.Summary) *internal.Metrics {
+	metrics := internal.NewMetrics(path, command)
+	metrics.AddSummary(summary)
+	metrics.AddTrackerAges(absDir, file)
+	return metrics
+}
+
+// statusMetrics builds the per-repository gauges of a status run
+// The outcome of each repository's last clone or pull comes from the last-run file
+func statusMetrics(path, absDir string, statuses []RepoStatus, duration time.Duration) *internal.Metrics {
+	metrics := internal.NewMetrics(path, "status")
+	metrics.AddRun(duration)
+
+	for _, status := range statuses {
+		labels := []string{"project", status.Project.Name, "group", status.Project.Group}
+		present := 0.0
+		if status.Exists && status.IsGitRepo {
+			present = 1
+		}
+		metrics.Gauge("syncx_repo_present", "1 if the repository exists locally as a git repository", present, labels...)
+		if present == 0 || status.IsEmpty {
+			continue
+		}
+		metrics.Gauge("syncx_repo_behind_commits", "Commits the current branch is behind its upstream", float64(status.Behind), labels...)
+		metrics.Gauge("syncx_repo_ahead_commits", "Commits the current branch is ahead of its upstream", float64(status.Ahead), labels...)
+		metrics.Gauge("syncx_repo_uncommitted_files", "Files with uncommitted changes", float64(status.Uncommitted), labels...)
+		metrics.Gauge("syncx_repo_stashes", "Stash entries", float64(status.Stashes), labels...)
+		metrics.Gauge("syncx_repo_unpushed_branches", "Local branches with commits that are not pushed", float64(len(status.UnpushedBranches)), labels...)
+		if commitDate, err := time.Parse(time.RFC3339, status.LastCommitDate); err == nil {
+			metrics.Gauge("syncx_repo_last_commit_timestamp_seconds", "Unix time of the last commit on HEAD", float64(commitDate.Unix()), labels...)
+		}
+	}
+
+	metrics.AddTrackerAges(absDir, file)
+	if lastRun, err := internal.LoadLastRun(absDir); err == nil && lastRun != nil {
+		metrics.AddOperationResults(lastRun.Summary.Results)
+	}
+	return metrics
+}
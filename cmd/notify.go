@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"time"
+
+	"olive-clone-assistant-v2/internal"
+)
+
+// notifyRun sends the notifications from the config file whose trigger matches the run
+// previous is the run recorded before this one, used by on_change triggers
+func notifyRun(command, absDir string, startTime time.Time, summary internal.Summary, previous *internal.LastRun, logger *internal.Logger) {
+	if dryRun || noNotify || len(config.Notifications) == 0 {
+		return
+	}
+
+	event := internal.NewNotificationEvent(command, absDir, file, startTime, summary, previous)
+	for _, notification := range config.Notifications {
+		if !notification.ShouldNotify(event) {
+			continue
+		}
+		if err := internal.SendNotification(notification, event); err != nil {
+			logger.Warning("%v", err)
+			continue
+		}
+		logger.Success("Notification sent: %s", notification.DisplayName())
+	}
+}
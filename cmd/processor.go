@@ -20,8 +20,7 @@ func processProjectsWithTracking(projectsToClone, projectsToPull, projectsUpToDa
 	var wg sync.WaitGroup
 
 	// Create clean progress bar with better formatting
-	bar := progressbar.NewOptions(totalProjects,
-		progressbar.OptionSetDescription("🚀 Processing repositories"),
+	bar := internal.NewProgressBar(totalProjects, "🚀 Processing repositories",
 		progressbar.OptionSetWidth(50),
 		progressbar.OptionShowCount(),
 		progressbar.OptionShowIts(),
@@ -55,6 +54,8 @@ func processProjectsWithTracking(projectsToClone, projectsToPull, projectsUpToDa
 		
 		mutex.Lock()
 		results = append(results, result)
+		emitter.Result(result)
+		internal.LogOperationResult(result)
 		// Update progress bar with current project info
 		bar.Describe(fmt.Sprintf("🚀 Processing: %s", project.Name))
 		bar.Add(1)
@@ -102,7 +103,7 @@ func processProjectsWithTracking(projectsToClone, projectsToPull, projectsUpToDa
 			if result.IsClone {
 				action = "Cloned"
 			}
-			color.New(color.FgGreen).Printf("   %s %s (%s)\n", action, result.Project.Name, result.Duration)
+			color.New(color.FgGreen).Printf("   %s %s (%s%s)\n", action, result.Project.Name, result.Duration, attemptsNote(result))
 		}
 		fmt.Println()
 	}
@@ -146,6 +147,10 @@ func processProjectsWithTracking(projectsToClone, projectsToPull, projectsUpToDa
 	for _, result := range results {
 		if result.Success {
 			summary.SuccessCount++
+			if result.Attempts > 1 {
+				summary.RetriedCount++
+				summary.RetriedProjects = append(summary.RetriedProjects, result.Project)
+			}
 			if result.IsClone {
 				summary.ClonedCount++
 			} else {
@@ -162,5 +167,33 @@ func processProjectsWithTracking(projectsToClone, projectsToPull, projectsUpToDa
 
 	summary.FailedProjects = failedProjects
 	summary.EmptyProjects = emptyProjects
+	summary.Results = results
 	return summary
-}
\ No newline at end of file
+}
+
+// attemptsNote describes how many attempts an operation needed when it was retried
+func attemptsNote(result internal.OperationResult) string {
+	if result.Attempts <= 1 {
+		return ""
+	}
+	return fmt.Sprintf(", %d attempts", result.Attempts)
+}
+
+// applyProjectTimeouts resolves clone/fetch budgets using inventory overrides and tracked durations
+func applyProjectTimeouts(projects []internal.ProjectInfo, absDir string) []internal.ProjectInfo {
+	tracker, err := internal.LoadOrCreateTracker(absDir, file)
+	if err != nil {
+		tracker = nil // Fall back to inventory overrides and global defaults
+	}
+	return internal.ApplyTimeouts(projects, tracker)
+}
+
+// recordResultsInTracker stores commit hashes and durations of finished operations
+func recordResultsInTracker(absDir string, summary internal.Summary, logger *internal.Logger) {
+	if dryRun {
+		return
+	}
+	if err := internal.RecordResultsInTracker(absDir, file, summary.Results); err != nil {
+		logger.Warning("Failed to update tracker: %v", err)
+	}
+}
@@ -0,0 +1,324 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"olive-clone-assistant-v2/internal"
+
+	"github.com/fatih/color"
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+var (
+	pruneDelete   bool
+	pruneForce    bool
+	pruneYes      bool
+	pruneMaxDepth int
+)
+
+// pruneCmd removes clones that are no longer in the inventory
+var pruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "🧹 Archive or delete clones that are no longer in the inventory",
+	Long: color.New(color.FgBlue, color.Bold).Sprint(`
+🧹 Prune Command
+================
+
+List the orphaned clones of the output directory and, after confirmation,
+move them to its archive/ folder (or delete them with --delete).
+
+• ➖ Projects that were removed from the inventory but are still on disk
+• ❓ Repositories on disk whose origin matches no inventory project
+• 🛡️  Repositories with uncommitted changes, stashes, unpushed commits or an
+     unfinished merge/rebase are left alone unless --force is given
+`),
+	Run: runPrune,
+}
+
+func init() {
+	rootCmd.AddCommand(pruneCmd)
+
+	pruneCmd.Flags().BoolVar(&pruneDelete, "delete", false, "Delete orphaned repositories instead of moving them to archive/")
+	pruneCmd.Flags().BoolVar(&pruneForce, "force", false, "Also prune repositories with local work that is not on any remote")
+	pruneCmd.Flags().BoolVarP(&pruneYes, "yes", "y", false, "Do not ask for confirmation")
+	pruneCmd.Flags().IntVarP(&pruneMaxDepth, "max-depth", "d", 10, "Maximum directory depth to search for repositories")
+}
+
+// PruneResult is the outcome for one orphaned repository
+type PruneResult struct {
+	internal.OrphanRepository
+	LocalWork   []string `json:"local_work,omitempty"`
+	Action      string   `json:"action"`
+	Destination string   `json:"destination,omitempty"`
+	Error       string   `json:"error,omitempty"`
+}
+
+// PruneSummary is the structured output of the prune command
+type PruneSummary struct {
+	Orphans  int           `json:"orphans"`
+	Archived int           `json:"archived"`
+	Deleted  int           `json:"deleted"`
+	Refused  int           `json:"refused"`
+	Failed   int           `json:"failed"`
+	DryRun   bool          `json:"dry_run"`
+	Results  []PruneResult `json:"results"`
+}
+
+func runPrune(cmd *cobra.Command, args []string) {
+	logger := internal.NewLogger(verbose)
+
+	// Show banner
+	logger.Banner()
+
+	inventory, err := internal.LoadInventory(file)
+	if err != nil {
+		logger.Error("Failed to load inventory: %v", err)
+		emitter.Error("Failed to load inventory: %v", err)
+		setExitCode(ExitConfigError)
+		return
+	}
+
+	// Use physical location as default directory if not specified via flags
+	if directory == "" && inventory.PhysicalLocation != "" {
+		directory = inventory.PhysicalLocation
+	}
+
+	absDir, err := filepath.Abs(directory)
+	if err != nil {
+		logger.Error("Failed to get absolute path for %s: %v", directory, err)
+		setExitCode(ExitConfigError)
+		return
+	}
+	if info, err := os.Stat(absDir); err != nil || !info.IsDir() {
+		logger.Error("Output directory does not exist: %s", absDir)
+		emitter.Error("Output directory does not exist: %s", absDir)
+		setExitCode(ExitConfigError)
+		return
+	}
+
+	tracker, err := internal.LoadOrCreateTracker(absDir, file)
+	if err != nil {
+		logger.Warning("Could not read tracker, only repositories on disk are checked: %v", err)
+		tracker = nil
+	}
+	projects := resolveStatusPaths(internal.CollectAllProjects(*inventory), absDir, tracker)
+
+	// Repositories that were already archived are not orphans any more
+	archiveDir := filepath.Join(absDir, internal.ArchiveDirName)
+	spinner := logger.StartSpinner(fmt.Sprintf("Searching %s for git repositories...", absDir))
+	var repoPaths []string
+	for _, path := range discoverGitRepositories(absDir, pruneMaxDepth) {
+		if path != archiveDir && !strings.HasPrefix(path, archiveDir+string(filepath.Separator)) {
+			repoPaths = append(repoPaths, path)
+		}
+	}
+	logger.StopSpinnerSuccess(spinner, fmt.Sprintf("Found %d git repositories", len(repoPaths)))
+
+	var orphans []internal.OrphanRepository
+	for _, orphan := range internal.FindOrphanRepositories(tracker, projects, repoPaths) {
+		// Never touch anything outside the output directory, nor the directory itself
+		if !internal.IsBelowDirectory(orphan.Path, absDir) {
+			logger.Warning("Skipping %s: not inside the output directory", orphan.Path)
+			continue
+		}
+		orphans = append(orphans, orphan)
+	}
+	summary := PruneSummary{Orphans: len(orphans), DryRun: dryRun, Results: []PruneResult{}}
+	if len(orphans) == 0 {
+		logger.Success("No orphaned repositories in %s", absDir)
+		emitter.Summary(summary)
+		return
+	}
+
+	var candidates []PruneResult
+	for _, orphan := range orphans {
+		result := PruneResult{OrphanRepository: orphan, LocalWork: describeLocalWork(orphan.Path)}
+		if len(result.LocalWork) > 0 && !pruneForce {
+			result.Action = "refused"
+			summary.Refused++
+			summary.Results = append(summary.Results, result)
+			continue
+		}
+		candidates = append(candidates, result)
+	}
+
+	displayOrphans(orphans, summary.Results, absDir)
+
+	action := "archive"
+	if pruneDelete {
+		action = "delete"
+	}
+
+	if len(candidates) == 0 {
+		logger.Warning("Every orphaned repository has local work; use --force to prune them anyway")
+		finishPrune(summary, logger)
+		return
+	}
+
+	if dryRun {
+		for _, candidate := range candidates {
+			logger.DryRun("Would %s %s", action, relativeTo(absDir, candidate.Path))
+			candidate.Action = "would-" + action
+			summary.Results = append(summary.Results, candidate)
+		}
+		finishPrune(summary, logger)
+		return
+	}
+
+	if !pruneYes {
+		confirmed, err := confirmPrune(action, len(candidates), archiveDir)
+		if err != nil {
+			logger.Error("%v", err)
+			emitter.Error("%v", err)
+			setExitCode(ExitConfigError)
+			return
+		}
+		if !confirmed {
+			logger.Warning("Prune cancelled, nothing was changed")
+			return
+		}
+	}
+
+	for _, candidate := range candidates {
+		if pruneDelete {
+			err = internal.DeleteRepository(candidate.Path, absDir)
+			candidate.Action = "deleted"
+		} else {
+			candidate.Destination, err = internal.ArchiveRepository(candidate.Path, absDir, archiveDir)
+			candidate.Action = "archived"
+		}
+
+		if err != nil {
+			candidate.Action = "failed"
+			candidate.Error = err.Error()
+			summary.Failed++
+			logger.Error("%v", err)
+		} else {
+			if pruneDelete {
+				summary.Deleted++
+				logger.Success("Deleted %s", relativeTo(absDir, candidate.Path))
+			} else {
+				summary.Archived++
+				logger.Success("Archived %s → %s", relativeTo(absDir, candidate.Path), relativeTo(absDir, candidate.Destination))
+			}
+			if tracker != nil {
+				internal.RemoveTrackedPath(tracker, candidate.Path)
+			}
+			internal.FileLog().Info("repository pruned", "path", candidate.Path, "action", candidate.Action, "destination", candidate.Destination, "forced", len(candidate.LocalWork) > 0)
+		}
+		summary.Results = append(summary.Results, candidate)
+	}
+
+	if tracker != nil && summary.Archived+summary.Deleted > 0 {
+		if err := internal.SaveTracker(tracker); err != nil {
+			logger.Warning("Failed to update tracker: %v", err)
+		}
+	}
+
+	finishPrune(summary, logger)
+}
+
+// describeLocalWork lists what would be lost by removing a repository
+func describeLocalWork(path string) []string {
+	var work []string
+	if clean, files := isWorkingDirectoryClean(path); !clean {
+		work = append(work, fmt.Sprintf("%d uncommitted changes", files))
+	}
+	if stashes := getStashCount(path); stashes > 0 {
+		work = append(work, fmt.Sprintf("%d stashes", stashes))
+	}
+	// A count that failed is treated as local work, so nothing is removed on a guess
+	if unpushed, err := countUnpushedCommits(path); err != nil {
+		work = append(work, "unpushed commits could not be counted")
+	} else if unpushed > 0 {
+		work = append(work, fmt.Sprintf("%d commits on no remote", unpushed))
+	}
+	if operation := getOperationInProgress(path); operation != "" {
+		work = append(work, operation+" in progress")
+	}
+	return work
+}
+
+// countUnpushedCommits counts the commits of HEAD (even detached) and the local branches that no
+// remote-tracking branch contains. An error means the commits could not be counted.
+func countUnpushedCommits(path string) (int, error) {
+	args := []string{"-C", path, "rev-list", "--count", "--branches", "--not", "--remotes"}
+	if !internal.IsEmptyRepository(path) {
+		args = []string{"-C", path, "rev-list", "--count", "HEAD", "--branches", "--not", "--remotes"}
+	}
+	output, err := internal.RunGitCommand(args...)
+	if err != nil {
+		return 0, err
+	}
+	var count int
+	if _, err := fmt.Sscanf(strings.TrimSpace(string(output)), "%d", &count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// confirmPrune asks before anything is removed; without a terminal --yes is required
+func confirmPrune(action string, count int, archiveDir string) (bool, error) {
+	if outputFormat != "text" || !term.IsTerminal(int(os.Stdin.Fd())) {
+		return false, fmt.Errorf("refusing to %s repositories without confirmation; re-run with --yes", action)
+	}
+
+	label := fmt.Sprintf("Move %d repositories to %s", count, archiveDir)
+	if action == "delete" {
+		label = fmt.Sprintf("Permanently delete %d repositories", count)
+	}
+	prompt := promptui.Prompt{Label: label, IsConfirm: true}
+	if _, err := prompt.Run(); err != nil {
+		if errors.Is(err, promptui.ErrInterrupt) {
+			setExitCode(ExitInterrupted)
+		}
+		return false, nil
+	}
+	return true, nil
+}
+
+func displayOrphans(orphans []internal.OrphanRepository, refused []PruneResult, absDir string) {
+	blocked := make(map[string][]string)
+	for _, result := range refused {
+		blocked[result.Path] = result.LocalWork
+	}
+
+	fmt.Println()
+	color.New(color.FgYellow, color.Bold).Printf("🧹 Orphaned repositories (%d):\n", len(orphans))
+	for _, orphan := range orphans {
+		marker, reason := "➖", "removed from the inventory"
+		if orphan.Reason == internal.OrphanNotInInventory {
+			marker, reason = "❓", "not in the inventory"
+		}
+		color.New(color.FgYellow).Printf("   %s %s (%s)\n", marker, relativeTo(absDir, orphan.Path), reason)
+		if work, ok := blocked[orphan.Path]; ok {
+			color.New(color.FgRed).Printf("      🛡️  Kept: %s\n", strings.Join(work, ", "))
+		}
+	}
+	fmt.Println()
+}
+
+func finishPrune(summary PruneSummary, logger *internal.Logger) {
+	if summary.Refused > 0 {
+		logger.Warning("%d repositories with local work were kept (use --force to prune them)", summary.Refused)
+		setExitCode(ExitDirtyRepos)
+	}
+	if summary.Failed > 0 {
+		setExitCode(ExitPartialFailure)
+	}
+	emitter.Summary(summary)
+}
+
+// relativeTo shortens a path below the output directory for display
+func relativeTo(absDir, path string) string {
+	if rel, err := filepath.Rel(absDir, path); err == nil && !strings.HasPrefix(rel, "..") {
+		return rel
+	}
+	return path
+}
@@ -14,8 +14,16 @@ import (
 )
 
 var (
-	pullParallel int
-	pullGroup    string
+	pullParallel    int
+	pullGroup       string
+	pullResume      bool
+	pullRetryFailed bool
+	pullReportFile  string
+	pullJUnitFile   string
+	pullMetricsFile string
+	pullInteractive bool
+	pullTUI         bool
+	pullChangelog   bool
 )
 
 // pullCmd represents the pull command
@@ -44,9 +52,21 @@ func init() {
 
 	pullCmd.Flags().IntVarP(&pullParallel, "parallel", "p", 10, "Number of parallel pull operations (1-20)")
 	pullCmd.Flags().StringVarP(&pullGroup, "group", "g", "", "Pull only repositories from specific group")
+	pullCmd.Flags().BoolVar(&pullResume, "resume", false, "Resume an interrupted pull run from its journal")
+	pullCmd.Flags().BoolVar(&pullRetryFailed, "retry-failed", false, "Only pull projects that failed or were empty in the previous run")
+	pullCmd.Flags().StringVar(&pullReportFile, "report", "", "Write an HTML (.html) or Markdown (.md) report to this file")
+	pullCmd.Flags().StringVar(&pullJUnitFile, "junit", "", "Write a JUnit XML report with one test case per repository to this file")
+	pullCmd.Flags().StringVar(&pullMetricsFile, "metrics-file", "", "Write Prometheus textfile-collector metrics to this file")
+	pullCmd.Flags().BoolVar(&pullTUI, "tui", false, "Show a full-screen table of every repository instead of the progress bar")
+	pullCmd.Flags().BoolVar(&pullChangelog, "changelog", false, "List the incoming commits of every updated repository after the summary")
+	pullCmd.Flags().BoolVarP(&pullInteractive, "interactive", "i", false, "Pick repositories and options in the wizard before pulling")
 }
 
 func runPull(cmd *cobra.Command, args []string) {
+	if pullInteractive && !runWizard("pull") {
+		return
+	}
+
 	logger := internal.NewLogger(verbose)
 	startTime := time.Now()
 
@@ -58,11 +78,22 @@ func runPull(cmd *cobra.Command, args []string) {
 		fmt.Println()
 	}
 
+	if !validateReportPath(pullReportFile, logger) {
+		return
+	}
+
+	if pullResume {
+		resumeInterruptedRun("pull", logger)
+		return
+	}
+
 	// Load inventory with spinner
 	spinnerLoad := logger.StartSpinner(fmt.Sprintf("Loading inventory from %s", file))
 	inventory, err := internal.LoadInventory(file)
 	if err != nil {
 		logger.StopSpinnerError(spinnerLoad, fmt.Sprintf("Failed to load inventory: %v", err))
+		emitter.Error("Failed to load inventory: %v", err)
+		setExitCode(ExitConfigError)
 		return
 	}
 	logger.StopSpinnerSuccess(spinnerLoad, "Inventory loaded successfully")
@@ -80,6 +111,7 @@ func runPull(cmd *cobra.Command, args []string) {
 	allProjects := internal.CollectAllProjects(*inventory)
 	if len(allProjects) == 0 {
 		logger.Warning("No projects found in inventory")
+		setExitCode(ExitConfigError)
 		return
 	}
 
@@ -90,6 +122,7 @@ func runPull(cmd *cobra.Command, args []string) {
 		filteredProjects := internal.FilterProjectsByGroup(allProjects, pullGroup)
 		if len(filteredProjects) == 0 {
 			logger.Warning("No projects found for group: %s", pullGroup)
+			setExitCode(ExitConfigError)
 			showAvailableGroups(allProjects, logger)
 			return
 		}
@@ -97,13 +130,29 @@ func runPull(cmd *cobra.Command, args []string) {
 		logger.Info("Filtered to %d projects in group: %s", len(allProjects), pullGroup)
 	}
 
+	// Keep only what was picked in the wizard
+	if wizardSelection != nil {
+		allProjects = applyWizardSelection(allProjects)
+		logger.Info("Using %d projects selected in the wizard", len(allProjects))
+	}
+
 	// Ensure output directory exists and is valid
 	absDir, err := internal.EnsureOutputDirectory(directory, logger)
 	if err != nil {
 		logger.Error("Output directory setup failed: %v", err)
+		emitter.Error("Output directory setup failed: %v", err)
+		setExitCode(ExitConfigError)
 		return
 	}
 
+	// Limit to what failed last time if requested
+	if pullRetryFailed {
+		var ok bool
+		if allProjects, ok = retryFailedProjects(allProjects, absDir, logger); !ok {
+			return
+		}
+	}
+
 	// Show output directory info if verbose
 	if verbose {
 		internal.ShowOutputDirectoryInfo(absDir, logger)
@@ -117,6 +166,13 @@ func runPull(cmd *cobra.Command, args []string) {
 	color.New(color.FgYellow).Printf("   Mode: Pull Only (existing repos only)\n")
 	fmt.Println()
 
+	// Move clones whose computed path changed before the tracked paths are used
+	relocations, err := internal.RelocateMovedProjects(allProjects, absDir, file, dryRun)
+	if err != nil {
+		logger.Warning("Could not relocate moved projects: %v", err)
+	}
+	displayRelocations(relocations, absDir, logger)
+
 	// Load tracker to find actually cloned repositories
 	spinnerScan := logger.StartSpinner("Scanning for existing repositories using tracker...")
 	tracker, err := internal.LoadOrCreateTracker(absDir, file)
@@ -140,9 +196,19 @@ func runPull(cmd *cobra.Command, args []string) {
 		}
 
 		// Process fallback
-		summary := processPullOperations(existingProjects, logger)
+		summary := processPullOperations(existingProjects, nil, logger)
 		summary.TotalDuration = time.Since(startTime).String()
+		previous := recordLastRun(absDir, "pull", startTime, summary, logger)
+		recordHistory(absDir, "pull", startTime, summary, logger)
+		recordResultsInTracker(absDir, summary, logger)
 		logger.Summary(summary)
+		showPullChangelog(summary, logger)
+		emitter.Summary(summary)
+		setExitCodeForSummary(summary)
+		writeReport(pullReportFile, summaryReport("pull", absDir, summary), logger)
+		writeJUnit(pullJUnitFile, summaryJUnit("pull", summary), startTime, logger)
+		writeMetrics(pullMetricsFile, summaryMetrics(pullMetricsFile, "pull", absDir, summary), logger)
+		notifyRun("pull", absDir, startTime, summary, previous, logger)
 		return
 	}
 
@@ -150,21 +216,19 @@ func runPull(cmd *cobra.Command, args []string) {
 	var existingProjects []internal.ProjectInfo
 	trackedCount := 0
 
+	matches := internal.MatchTrackedProjects(tracker, allProjects)
 	for _, project := range allProjects {
-		// Find project in tracker by URL
-		for _, trackedProject := range tracker.Projects {
-			if trackedProject.URL == project.URL {
-				// Use the tracked local path (actual location)
-				project.LocalPath = trackedProject.LocalPath
-
-				// Verify it still exists
-				if _, err := os.Stat(project.LocalPath); err == nil {
-					if internal.IsGitRepository(project.LocalPath) {
-						existingProjects = append(existingProjects, project)
-						trackedCount++
-					}
+		// Find project in tracker by its stable identity
+		if index, ok := matches[internal.ProjectIdentity(project)]; ok {
+			// Use the tracked local path (actual location)
+			project.LocalPath = tracker.Projects[index].LocalPath
+
+			// Verify it still exists
+			if _, err := os.Stat(project.LocalPath); err == nil {
+				if internal.IsGitRepository(project.LocalPath) {
+					existingProjects = append(existingProjects, project)
+					trackedCount++
 				}
-				break
 			}
 		}
 	}
@@ -177,85 +241,142 @@ func runPull(cmd *cobra.Command, args []string) {
 		return
 	}
 
+	// Resolve per-project fetch budgets (inventory overrides, adaptive from tracker)
+	existingProjects = applyProjectTimeouts(existingProjects, absDir)
+
+	// Record the plan so an interrupted run can be resumed
+	var journal *internal.RunJournal
+	if !dryRun {
+		journal = internal.NewRunJournal("pull", absDir, file, protocol, pullParallel, pullGroup, existingProjects, false)
+		if err := internal.SaveJournal(journal); err != nil {
+			logger.Warning("Could not write run journal: %v", err)
+		} else {
+			resumeHint = true
+		}
+	}
+
 	// Process only existing projects
-	summary := processPullOperations(existingProjects, logger)
+	summary := processPullOperations(existingProjects, journal, logger)
+	summary.Relocations = relocations
 	summary.TotalDuration = time.Since(startTime).String()
+	previous := recordLastRun(absDir, "pull", startTime, summary, logger)
+	recordHistory(absDir, "pull", startTime, summary, logger)
+	recordResultsInTracker(absDir, summary, logger)
+
+	// Repositories skipped in the terminal UI stay in the journal for 'syncx resume'
+	if journal != nil && summary.SkippedCount == 0 {
+		if err := internal.RemoveJournal(absDir); err != nil {
+			logger.Warning("%v", err)
+		}
+	} else if journal != nil {
+		logger.Info("💡 Run 'syncx resume' to process the %d skipped repositories", summary.SkippedCount)
+	}
 
 	// Show summary
 	logger.Summary(summary)
+	showPullChangelog(summary, logger)
+	emitter.Summary(summary)
+	setExitCodeForSummary(summary)
+	writeReport(pullReportFile, summaryReport("pull", absDir, summary), logger)
+	writeJUnit(pullJUnitFile, summaryJUnit("pull", summary), startTime, logger)
+	writeMetrics(pullMetricsFile, summaryMetrics(pullMetricsFile, "pull", absDir, summary), logger)
+	notifyRun("pull", absDir, startTime, summary, previous, logger)
 
 	if dryRun {
 		color.New(color.FgYellow, color.Bold).Println("\n💡 This was a dry run. Run without --dry-run to execute operations.")
 	}
 }
 
-func processPullOperations(projects []internal.ProjectInfo, logger *internal.Logger) internal.Summary {
+// showPullChangelog lists the commits the run pulled in when --changelog is given
+func showPullChangelog(summary internal.Summary, logger *internal.Logger) {
+	if !pullChangelog || dryRun {
+		return
+	}
+	displayChangelog("🧾 Incoming Commits", internal.ChangelogFromSummary(summary, internal.ChangelogFilter{}), logger)
+	logger.Info("💡 Use 'syncx changes' to filter by author or path, or export the changelog as Markdown")
+}
+
+// pullOperation pulls one project, or only describes the pull in dry-run mode
+func pullOperation(project internal.ProjectInfo) internal.OperationResult {
+	if dryRun {
+		return internal.OperationResult{
+			Success:  true,
+			Project:  project,
+			Message:  fmt.Sprintf("DRY RUN: Would pull updates for %s", project.Name),
+			IsClone:  false,
+			Duration: "0s",
+		}
+	}
+	before, _ := internal.GetCurrentCommitHash(project.LocalPath)
+	result := internal.PullRepositorySilent(project.LocalPath, project.FetchTimeout)
+	result.Project = project
+	result.BeforeCommit = before
+	result.AfterCommit, _ = internal.GetCurrentCommitHash(project.LocalPath)
+	return result
+}
+
+func processPullOperations(projects []internal.ProjectInfo, journal *internal.RunJournal, logger *internal.Logger) internal.Summary {
 	totalProjects := len(projects)
 	
 	var results []internal.OperationResult
 	var mutex sync.Mutex
 	var wg sync.WaitGroup
 
-	// Create clean progress bar that stays on one line
-	bar := progressbar.NewOptions(totalProjects,
-		progressbar.OptionSetDescription("🔄 Pulling updates"),
-		progressbar.OptionSetWidth(50),
-		progressbar.OptionShowCount(),
-		progressbar.OptionShowIts(),
-		progressbar.OptionSetItsString("repos"),
-		progressbar.OptionThrottle(65*time.Millisecond),
-		progressbar.OptionShowElapsedTimeOnFinish(),
-		progressbar.OptionSetTheme(progressbar.Theme{
-			Saucer:        "█",
-			SaucerHead:    "█",
-			SaucerPadding: "░",
-			BarStart:      "[",
-			BarEnd:        "]",
-		}),
-		progressbar.OptionSetRenderBlankState(true),
-		progressbar.OptionClearOnFinish(),
-	)
-
-	// Create semaphore for parallel processing
-	semaphore := make(chan struct{}, pullParallel)
-
-	// Process function
-	processProject := func(project internal.ProjectInfo) {
-		defer wg.Done()
-		semaphore <- struct{}{}
-		defer func() { <-semaphore }()
-
-		// For pull-only mode, we only do pull operations
-		var result internal.OperationResult
-		if dryRun {
-			result = internal.OperationResult{
-				Success:  true,
-				Project:  project,
-				Message:  fmt.Sprintf("DRY RUN: Would pull updates for %s", project.Name),
-				IsClone:  false,
-				Duration: "0s",
-			}
-		} else {
-			result = internal.PullRepositorySilent(project.LocalPath)
-			result.Project = project
+	skipped := 0
+	if tuiResults, tuiSkipped, ok := runWithTUI(pullTUI, "🔄 Pulling updates", projects, pullParallel, pullOperation, journal, logger); ok {
+		results, skipped = tuiResults, tuiSkipped
+	} else {
+		// Create clean progress bar that stays on one line
+		bar := internal.NewProgressBar(totalProjects, "🔄 Pulling updates",
+			progressbar.OptionSetWidth(50),
+			progressbar.OptionShowCount(),
+			progressbar.OptionShowIts(),
+			progressbar.OptionSetItsString("repos"),
+			progressbar.OptionThrottle(65*time.Millisecond),
+			progressbar.OptionShowElapsedTimeOnFinish(),
+			progressbar.OptionSetTheme(progressbar.Theme{
+				Saucer:        "█",
+				SaucerHead:    "█",
+				SaucerPadding: "░",
+				BarStart:      "[",
+				BarEnd:        "]",
+			}),
+			progressbar.OptionSetRenderBlankState(true),
+			progressbar.OptionClearOnFinish(),
+		)
+
+		// Create semaphore for parallel processing
+		semaphore := make(chan struct{}, pullParallel)
+
+		// Process function
+		processProject := func(project internal.ProjectInfo) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			// For pull-only mode, we only do pull operations
+			result := pullOperation(project)
+			internal.MarkJournalJob(journal, result)
+
+			mutex.Lock()
+			results = append(results, result)
+			emitter.Result(result)
+			internal.LogOperationResult(result)
+			bar.Add(1)
+			mutex.Unlock()
 		}
-		
-		mutex.Lock()
-		results = append(results, result)
-		bar.Add(1)
-		mutex.Unlock()
-	}
 
-	// Start pull operations
-	for _, project := range projects {
-		wg.Add(1)
-		go processProject(project)
-	}
+		// Start pull operations
+		for _, project := range projects {
+			wg.Add(1)
+			go processProject(project)
+		}
 
-	// Wait for all operations to complete
-	wg.Wait()
-	bar.Finish()
-	fmt.Println()
+		// Wait for all operations to complete
+		wg.Wait()
+		bar.Finish()
+		fmt.Println()
+	}
 
 	// Show detailed results after progress bar completes
 	logger.Header("📊 Pull Results")
@@ -275,7 +396,7 @@ func processPullOperations(projects []internal.ProjectInfo, logger *internal.Log
 	if len(successfulOps) > 0 {
 		color.New(color.FgGreen, color.Bold).Printf("✅ Successfully Updated (%d):\n", len(successfulOps))
 		for _, result := range successfulOps {
-			color.New(color.FgGreen).Printf("   %s (%s)\n", result.Project.Name, result.Duration)
+			color.New(color.FgGreen).Printf("   %s (%s%s)\n", result.Project.Name, result.Duration, attemptsNote(result))
 		}
 		fmt.Println()
 	}
@@ -301,6 +422,7 @@ func processPullOperations(projects []internal.ProjectInfo, logger *internal.Log
 	// Calculate summary
 	summary := internal.Summary{
 		TotalProjects: totalProjects,
+		SkippedCount:  skipped,
 	}
 
 	var failedProjects []internal.ProjectInfo
@@ -309,6 +431,10 @@ func processPullOperations(projects []internal.ProjectInfo, logger *internal.Log
 	for _, result := range results {
 		if result.Success {
 			summary.SuccessCount++
+			if result.Attempts > 1 {
+				summary.RetriedCount++
+				summary.RetriedProjects = append(summary.RetriedProjects, result.Project)
+			}
 			summary.UpdatedCount++ // All operations in pull mode are updates
 		} else if result.IsEmpty {
 			summary.EmptyCount++
@@ -321,5 +447,6 @@ func processPullOperations(projects []internal.ProjectInfo, logger *internal.Log
 
 	summary.FailedProjects = failedProjects
 	summary.EmptyProjects = emptyProjects
+	summary.Results = results
 	return summary
 }
\ No newline at end of file
@@ -0,0 +1,195 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"olive-clone-assistant-v2/internal"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var remotesGroup string
+
+// remotesCmd groups the commands that maintain the origin remotes of the clones
+var remotesCmd = &cobra.Command{
+	Use:   "remotes",
+	Short: "🔗 Keep the origin remotes of the clones in line with the inventory",
+	Long: color.New(color.FgBlue, color.Bold).Sprint(`
+🔗 Remotes Command
+==================
+
+A clone keeps the origin it was cloned with. When a repository moves to a
+new namespace in the inventory, or --protocol changes, 'syncx status'
+reports the drift and 'syncx remotes fix' repairs it.
+`),
+}
+
+// remotesFixCmd points drifted origins at the inventory URL
+var remotesFixCmd = &cobra.Command{
+	Use:   "fix",
+	Short: "🔧 Point drifted origin remotes at the inventory URL",
+	Long: color.New(color.FgBlue, color.Bold).Sprint(`
+🔧 Remotes Fix
+==============
+
+Run 'git remote set-url origin' in every clone whose origin points somewhere
+else than its inventory URL, and update the tracker.
+
+• 🔗 Without --protocol each clone keeps the protocol it uses now
+• 🔐 With --protocol, origins using the other protocol are switched too
+• 👀 --dry-run lists the changes without making them
+`),
+	Run: runRemotesFix,
+}
+
+func init() {
+	rootCmd.AddCommand(remotesCmd)
+	remotesCmd.AddCommand(remotesFixCmd)
+
+	remotesFixCmd.Flags().StringVarP(&remotesGroup, "group", "g", "", "Only fix repositories from specific group")
+}
+
+// RemoteFix is the outcome for one clone whose origin drifted
+type RemoteFix struct {
+	Project internal.ProjectInfo `json:"project"`
+	From    string               `json:"from"`
+	To      string               `json:"to"`
+	Drift   string               `json:"drift"`
+	Status  string               `json:"status"` // "fixed", "would-fix" or "failed"
+	Error   string               `json:"error,omitempty"`
+}
+
+// RemotesFixSummary is the structured output of remotes fix
+type RemotesFixSummary struct {
+	Checked int         `json:"checked"`
+	Drifted int         `json:"drifted"`
+	Fixed   int         `json:"fixed"`
+	Failed  int         `json:"failed"`
+	DryRun  bool        `json:"dry_run"`
+	Fixes   []RemoteFix `json:"fixes"`
+}
+
+// driftProtocol is the protocol origins are checked against: only an explicit --protocol asks
+// for one, otherwise every clone keeps the protocol it was cloned with
+func driftProtocol() string {
+	if rootCmd.PersistentFlags().Changed("protocol") {
+		return protocol
+	}
+	return ""
+}
+
+func runRemotesFix(cmd *cobra.Command, args []string) {
+	logger := internal.NewLogger(verbose)
+
+	// Show banner
+	logger.Banner()
+
+	inventory, err := internal.LoadInventory(file)
+	if err != nil {
+		logger.Error("Failed to load inventory: %v", err)
+		emitter.Error("Failed to load inventory: %v", err)
+		setExitCode(ExitConfigError)
+		return
+	}
+
+	// Use physical location as default directory if not specified via flags
+	if directory == "" && inventory.PhysicalLocation != "" {
+		directory = inventory.PhysicalLocation
+	}
+
+	allProjects := internal.CollectAllProjects(*inventory)
+	if remotesGroup != "" {
+		allProjects = internal.FilterProjectsByGroup(allProjects, remotesGroup)
+	}
+	if len(allProjects) == 0 {
+		logger.Warning("No projects found in inventory")
+		setExitCode(ExitConfigError)
+		return
+	}
+
+	absDir, err := filepath.Abs(directory)
+	if err != nil {
+		logger.Error("Failed to get absolute path for %s: %v", directory, err)
+		setExitCode(ExitConfigError)
+		return
+	}
+	if info, err := os.Stat(absDir); err != nil || !info.IsDir() {
+		logger.Error("Output directory does not exist: %s", absDir)
+		emitter.Error("Output directory does not exist: %s", absDir)
+		setExitCode(ExitConfigError)
+		return
+	}
+
+	tracker, err := internal.LoadOrCreateTracker(absDir, file)
+	if err != nil {
+		logger.Warning("Could not read tracker, using inventory paths: %v", err)
+		tracker = nil
+	}
+	projects := resolveStatusPaths(allProjects, absDir, tracker)
+
+	summary := RemotesFixSummary{DryRun: dryRun, Fixes: []RemoteFix{}}
+	fmt.Println()
+	for _, project := range projects {
+		if !internal.IsGitRepository(project.LocalPath) {
+			continue
+		}
+		summary.Checked++
+
+		origin, err := internal.GetRemoteURL(project.LocalPath)
+		if err != nil || origin == "" {
+			continue
+		}
+		expected, drift := internal.CheckRemoteDrift(project, origin, driftProtocol())
+		if drift == "" {
+			continue
+		}
+		summary.Drifted++
+
+		fix := RemoteFix{Project: project, From: origin, To: expected, Drift: drift}
+		if dryRun {
+			fix.Status = "would-fix"
+			logger.DryRun("Would set origin of %s: %s → %s", project.Name, origin, expected)
+		} else if err := internal.SetRemoteURL(project.LocalPath, expected); err != nil {
+			fix.Status = "failed"
+			fix.Error = err.Error()
+			summary.Failed++
+			logger.Error("%s: %v", project.Name, err)
+		} else {
+			fix.Status = "fixed"
+			summary.Fixed++
+			color.New(color.FgGreen).Printf("✅ %s: %s → %s\n", project.Name, origin, expected)
+			internal.FileLog().Info("remote fixed", "project", project.Name, "path", project.LocalPath, "from", origin, "to", expected)
+
+			if tracker != nil {
+				if i := internal.FindTrackedProject(tracker, project); i >= 0 {
+					tracker.Projects[i].URL = project.URL
+					tracker.Projects[i].GitURL = expected
+				}
+			}
+		}
+		summary.Fixes = append(summary.Fixes, fix)
+	}
+
+	if tracker != nil && summary.Fixed > 0 {
+		if err := internal.SaveTracker(tracker); err != nil {
+			logger.Warning("Failed to update tracker: %v", err)
+		}
+	}
+
+	fmt.Println()
+	switch {
+	case summary.Drifted == 0:
+		logger.Success("All %d origins match the inventory", summary.Checked)
+	case dryRun:
+		logger.Info("%d of %d origins would be updated", summary.Drifted, summary.Checked)
+	default:
+		logger.Info("Updated %d of %d drifted origins", summary.Fixed, summary.Drifted)
+	}
+	if summary.Failed > 0 {
+		setExitCode(ExitPartialFailure)
+	}
+	emitter.Summary(summary)
+}
@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"fmt"
+
+	"olive-clone-assistant-v2/internal"
+)
+
+// reportChangeColumns are shared by the dirty sections of check and scan reports
+var reportChangeColumns = []string{"Repository", "Branch", "Modified", "Staged", "Untracked"}
+
+// validateReportPath rejects a --report file with an unknown extension before any work is done
+func validateReportPath(path string, logger *internal.Logger) bool {
+	if path == "" {
+		return true
+	}
+	if _, err := internal.ReportFormat(path); err != nil {
+		logger.Error("%v", err)
+		emitter.Error("%v", err)
+		setExitCode(ExitConfigError)
+		return false
+	}
+	return true
+}
+
+// writeReport renders the report if --report was given
+func writeReport(path string, report *internal.Report, logger *internal.Logger) {
+	if path == "" {
+		return
+	}
+	if err := internal.WriteReport(path, report); err != nil {
+		logger.Error("%v", err)
+		setExitCode(ExitPartialFailure)
+		return
+	}
+	logger.Success("Report written to %s", path)
+}
+
+// summaryReport builds the report of a clone or pull run
+func summaryReport(command, absDir string, summary internal.Summary) *internal.Report {
+	report := internal.NewReport(command, absDir, file)
+	report.AddSummary(summary)
+	return report
+}
+
+// statusReport builds the report of a status run
+func statusReport(absDir string, statuses []RepoStatus, duration string) *internal.Report {
+	report := internal.NewReport("status", absDir, file)
+	report.Duration = duration
+
+	var missing, empty, dirty, behind, ahead, notGit [][]string
+	var stashed, unpushed, inProgress, detached, wrongBranch [][]string
+	for _, status := range statuses {
+		name, group := status.Project.Name, status.Project.Group
+		if status.Stashes > 0 {
+			stashed = append(stashed, []string{name, group, fmt.Sprintf("%d", status.Stashes)})
+		}
+		for _, branch := range status.UnpushedBranches {
+			upstream := "yes"
+			if branch.NoUpstream {
+				upstream = "no"
+			}
+			unpushed = append(unpushed, []string{name, group, branch.Name, fmt.Sprintf("%d", branch.Ahead), upstream})
+		}
+		if status.Operation != "" {
+			inProgress = append(inProgress, []string{name, group, status.Operation})
+		}
+		if status.Detached {
+			detached = append(detached, []string{name, group, status.Commit})
+		}
+		if status.WrongBranch {
+			wrongBranch = append(wrongBranch, []string{name, group, status.Branch, status.ExpectedBranch})
+		}
+		switch {
+		case !status.Exists:
+			missing = append(missing, []string{name, group, status.Project.LocalPath})
+		case !status.IsGitRepo:
+			notGit = append(notGit, []string{name, group, status.Project.LocalPath})
+		case status.IsEmpty:
+			empty = append(empty, []string{name, group, status.Project.URL})
+		default:
+			if !status.IsClean {
+				dirty = append(dirty, []string{name, group, status.Branch, fmt.Sprintf("%d", status.Uncommitted)})
+			}
+			if status.Behind > 0 {
+				behind = append(behind, []string{name, group, status.Branch, fmt.Sprintf("%d", status.Behind)})
+			}
+			if status.Ahead > 0 {
+				ahead = append(ahead, []string{name, group, status.Branch, fmt.Sprintf("%d", status.Ahead)})
+			}
+		}
+	}
+
+	summary := summarizeStatuses(statuses)
+	report.AddStat("Total", summary.Total)
+	report.AddStat("Missing", summary.Missing)
+	report.AddStat("Empty", summary.Empty)
+	report.AddStat("Dirty", summary.Dirty)
+	report.AddStat("Behind", summary.Behind)
+	report.AddStat("Ahead", summary.Ahead)
+	report.AddStat("Not git repos", summary.NotGitRepos)
+	report.AddStat("With stashes", summary.Stashed)
+	report.AddStat("With unpushed branches", summary.Unpushed)
+	report.AddStat("In progress", summary.InProgress)
+	report.AddStat("Detached", summary.Detached)
+	report.AddStat("Wrong branch", summary.WrongBranch)
+
+	report.AddSection("Missing", []string{"Project", "Group", "Expected at"}, missing)
+	report.AddSection("Not Git Repositories", []string{"Project", "Group", "Path"}, notGit)
+	report.AddSection("Empty", []string{"Project", "Group", "URL"}, empty)
+	report.AddSection("Dirty", []string{"Project", "Group", "Branch", "Uncommitted files"}, dirty)
+	report.AddSection("Behind", []string{"Project", "Group", "Branch", "Commits behind"}, behind)
+	report.AddSection("Ahead", []string{"Project", "Group", "Branch", "Commits ahead"}, ahead)
+	report.AddSection("Unfinished Merge or Rebase", []string{"Project", "Group", "Operation"}, inProgress)
+	report.AddSection("Stashes", []string{"Project", "Group", "Stashes"}, stashed)
+	report.AddSection("Unpushed Branches", []string{"Project", "Group", "Branch", "Commits", "Upstream"}, unpushed)
+	report.AddSection("Detached HEAD", []string{"Project", "Group", "Commit"}, detached)
+	report.AddSection("Not on Inventory Branch", []string{"Project", "Group", "Branch", "Expected"}, wrongBranch)
+	return report
+}
+
+// checkReport builds the report of a check run
+func checkReport(absDir string, results []CheckResult, summary ChangesSummary) *internal.Report {
+	report := internal.NewReport("check", absDir, file)
+
+	var failed, dirty [][]string
+	for _, result := range results {
+		if result.Error != "" {
+			failed = append(failed, []string{result.Project.Name, result.Error})
+		} else if result.HasChanges {
+			dirty = append(dirty, []string{result.Project.Name, result.Branch,
+				fmt.Sprintf("%d", result.ModifiedFiles), fmt.Sprintf("%d", result.StagedFiles), fmt.Sprintf("%d", result.UntrackedFiles)})
+		}
+	}
+
+	addChangesSummary(report, summary, failed, dirty)
+	return report
+}
+
+// scanReport builds the report of a scan run
+func scanReport(scanRoot string, results []ScanResult, summary ChangesSummary) *internal.Report {
+	report := internal.NewReport("scan", "", "")
+	report.OutputDirectory = scanRoot
+
+	var failed, dirty [][]string
+	for _, result := range results {
+		if result.Error != "" {
+			failed = append(failed, []string{result.Path, result.Error})
+		} else if result.HasChanges {
+			dirty = append(dirty, []string{result.Path, result.Branch,
+				fmt.Sprintf("%d", result.ModifiedFiles), fmt.Sprintf("%d", result.StagedFiles), fmt.Sprintf("%d", result.UntrackedFiles)})
+		}
+	}
+
+	addChangesSummary(report, summary, failed, dirty)
+	return report
+}
+
+// addChangesSummary adds the check/scan totals and sections
+func addChangesSummary(report *internal.Report, summary ChangesSummary, failed, dirty [][]string) {
+	report.Duration = summary.Duration
+
+	report.AddStat("Total", summary.Total)
+	report.AddStat("Clean", summary.Clean)
+	report.AddStat("Modified", summary.Modified)
+	report.AddStat("Staged", summary.Staged)
+	report.AddStat("Untracked", summary.Untracked)
+	report.AddStat("Errors", summary.Errors)
+
+	report.AddSection("Failed", []string{"Repository", "Error"}, failed)
+	report.AddSection("Dirty", reportChangeColumns, dirty)
+}
@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"olive-clone-assistant-v2/internal"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// resumeCmd represents the resume command
+var resumeCmd = &cobra.Command{
+	Use:   "resume",
+	Short: "⏯️  Resume an interrupted clone or pull run",
+	Long: color.New(color.FgBlue, color.Bold).Sprint(`
+⏯️  Resume Command
+=================
+
+Continue a clone or pull run that was interrupted (VPN drop, laptop sleep, Ctrl+C).
+Every run keeps a journal next to the tracker in the output directory.
+This command will:
+
+• 📖 Read the journal of the interrupted run
+• 🧹 Remove partial clone directories left behind
+• ⚙️  Reuse the original protocol and parallel settings
+• ⏩ Process only the jobs that never finished
+`),
+	Run: runResume,
+}
+
+func init() {
+	rootCmd.AddCommand(resumeCmd)
+}
+
+func runResume(cmd *cobra.Command, args []string) {
+	logger := internal.NewLogger(verbose)
+
+	// Show banner
+	logger.Banner()
+
+	if dryRun {
+		color.New(color.FgYellow, color.Bold).Println("🔍 DRY RUN MODE - No actual operations will be performed")
+		fmt.Println()
+	}
+
+	resumeInterruptedRun("", logger)
+}
+
+// resumeInterruptedRun continues the run recorded in the output directory journal
+// When command is not empty, the journal must belong to that command
+func resumeInterruptedRun(command string, logger *internal.Logger) {
+	startTime := time.Now()
+
+	absDir, err := filepath.Abs(directory)
+	if err != nil {
+		logger.Error("Failed to get absolute path for %s: %v", directory, err)
+		setExitCode(ExitConfigError)
+		return
+	}
+
+	journal, err := internal.LoadJournal(absDir)
+	if err != nil {
+		logger.Error("%v", err)
+		setExitCode(ExitConfigError)
+		return
+	}
+	if journal == nil {
+		logger.Warning("No interrupted run found in %s", absDir)
+		return
+	}
+	if command != "" && journal.Command != command {
+		logger.Warning("The interrupted run in %s was a '%s', not a '%s'", absDir, journal.Command, command)
+		logger.Info("💡 Use 'syncx resume' to continue it")
+		return
+	}
+
+	// Restore the original settings
+	protocol = journal.Protocol
+	file = journal.InventoryFile
+
+	logger.Header("⏯️  Resuming Interrupted Run")
+	color.New(color.FgCyan).Printf("   Command: %s\n", journal.Command)
+	color.New(color.FgCyan).Printf("   Started: %s\n", journal.StartedAt)
+	color.New(color.FgCyan).Printf("   Last progress: %s\n", journal.UpdatedAt)
+	color.New(color.FgCyan).Printf("   Output Directory: %s\n", journal.OutputDirectory)
+	color.New(color.FgCyan).Printf("   Protocol: %s\n", journal.Protocol)
+	color.New(color.FgCyan).Printf("   Parallel: %d\n", journal.Parallel)
+	if journal.Group != "" {
+		color.New(color.FgCyan).Printf("   Group: %s\n", journal.Group)
+	}
+	fmt.Println()
+
+	if dryRun {
+		for _, project := range internal.UnfinishedJournalProjects(journal) {
+			logger.DryRun("Would resume %s: %s", journal.Command, project.Name)
+		}
+		return
+	}
+
+	if removed := internal.CleanPartialClones(journal, logger); removed > 0 {
+		logger.Success("Removed %d partial clone directories", removed)
+	}
+
+	projects := internal.UnfinishedJournalProjects(journal)
+	if len(projects) == 0 {
+		logger.Success("Nothing left to resume, all %d jobs finished", len(journal.Jobs))
+		if err := internal.RemoveJournal(absDir); err != nil {
+			logger.Warning("%v", err)
+		}
+		return
+	}
+
+	logger.Info("⏩ %d of %d jobs left to run", len(projects), len(journal.Jobs))
+	resumeHint = true
+
+	var summary internal.Summary
+	switch journal.Command {
+	case "clone":
+		parallel = journal.Parallel
+		summary = processCloneOnly(projects, journal, logger)
+	case "pull":
+		pullParallel = journal.Parallel
+		summary = processPullOperations(projects, journal, logger)
+	default:
+		logger.Error("Unknown command in run journal: %s", journal.Command)
+		setExitCode(ExitConfigError)
+		return
+	}
+	summary.TotalDuration = time.Since(startTime).String()
+	previous := recordLastRun(absDir, journal.Command, startTime, summary, logger)
+	recordHistory(absDir, journal.Command, startTime, summary, logger)
+	recordResultsInTracker(absDir, summary, logger)
+
+	if err := internal.RemoveJournal(absDir); err != nil {
+		logger.Warning("%v", err)
+	}
+
+	logger.Summary(summary)
+	emitter.Summary(summary)
+	setExitCodeForSummary(summary)
+	notifyRun(journal.Command, absDir, startTime, summary, previous, logger)
+}
@@ -1,9 +1,13 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
+
+	"olive-clone-assistant-v2/internal"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
@@ -23,6 +27,41 @@ var (
 	directory string
 	file      string
 	outputDir string
+
+	// Retry flags for transient network failures
+	retries     int
+	retryDelay  time.Duration
+	retryJitter float64
+
+	// Timeout flags for network operations
+	cloneTimeout     time.Duration
+	fetchTimeout     time.Duration
+	adaptiveTimeouts bool
+
+	// Per-host limits for network operations
+	hostLimits        map[string]int
+	requestsPerMinute int
+
+	// Loaded configuration file
+	config = &internal.Config{}
+
+	// Machine-readable output
+	outputFormat string
+	emitter      *internal.Emitter
+
+	// Plain output for cron, CI and pipes
+	plainMode bool
+	noColor   bool
+
+	// Structured log file
+	logFile       string
+	logLevel      string
+	logFormat     string
+	logMaxSize    int
+	logMaxBackups int
+
+	// Notifications from the config file
+	noNotify bool
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -49,10 +88,19 @@ Use 'syncx [command] --help' for more information about a command.`),
 		// Setup global configuration
 		setupGlobals(cmd)
 	},
+	PersistentPostRun: func(cmd *cobra.Command, args []string) {
+		// Write the JSON document (NDJSON has already been streamed)
+		emitter.Flush()
+
+		internal.FileLog().Info("run finished", "command", cmd.CommandPath(), "exit_code", ExitCode())
+		internal.CloseLogFile()
+	},
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
+// Use ExitCode afterwards to get the process exit code
 func Execute() error {
+	handleInterrupts()
 	return rootCmd.Execute()
 }
 
@@ -67,6 +115,23 @@ func init() {
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Enable verbose output")
 	rootCmd.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "Show what would be done without executing")
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "Config file (default is $HOME/.olive-clone.yaml)")
+	rootCmd.PersistentFlags().IntVar(&retries, "retries", 2, "Retries for clones/pulls that fail with a transient network error")
+	rootCmd.PersistentFlags().DurationVar(&retryDelay, "retry-delay", 2*time.Second, "Base delay before the first retry (doubled on each retry)")
+	rootCmd.PersistentFlags().Float64Var(&retryJitter, "retry-jitter", 0.2, "Random spread applied to retry delays (0-1)")
+	rootCmd.PersistentFlags().DurationVar(&cloneTimeout, "clone-timeout", 60*time.Second, "Timeout for a single clone attempt (per-project clone_timeout in the inventory overrides it)")
+	rootCmd.PersistentFlags().DurationVar(&fetchTimeout, "fetch-timeout", 30*time.Second, "Timeout for a single fetch or pull attempt (per-project fetch_timeout in the inventory overrides it)")
+	rootCmd.PersistentFlags().BoolVar(&adaptiveTimeouts, "adaptive-timeouts", true, "Give repositories that previously took long a proportionally larger timeout")
+	rootCmd.PersistentFlags().StringToIntVar(&hostLimits, "host-limit", nil, "Max concurrent git operations per host, e.g. gitlab.internal=4,github.com=8 (\"*\" for other hosts)")
+	rootCmd.PersistentFlags().IntVar(&requestsPerMinute, "rate-limit", 0, "Max git operations started per minute and host (0 = unlimited)")
+	rootCmd.PersistentFlags().StringVar(&outputFormat, "output-format", "text", "Output format: text, json or ndjson (structured data on stdout, progress on stderr)")
+	rootCmd.PersistentFlags().BoolVar(&plainMode, "plain", false, "Plain line-oriented output: no colors, emoji, banner, spinners or progress bars (automatic when not a terminal)")
+	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "Disable colors (implies --plain; NO_COLOR is honoured too)")
+	rootCmd.PersistentFlags().StringVar(&logFile, "log-file", "", "Structured log file (default: .olive-clone.log in the output directory)")
+	rootCmd.PersistentFlags().StringVar(&logLevel, "log-level", "info", "Log file level: debug, info, warn or error (independent of --verbose)")
+	rootCmd.PersistentFlags().StringVar(&logFormat, "log-format", "json", "Log file format: json or logfmt")
+	rootCmd.PersistentFlags().IntVar(&logMaxSize, "log-max-size", 10, "Rotate the log file after this many megabytes")
+	rootCmd.PersistentFlags().IntVar(&logMaxBackups, "log-max-backups", 5, "Number of rotated log files to keep")
+	rootCmd.PersistentFlags().BoolVar(&noNotify, "no-notify", false, "Don't send the notifications configured in the config file")
 
 	// Mark directory as deprecated
 	rootCmd.PersistentFlags().MarkDeprecated("directory", "use --output or -o instead")
@@ -76,33 +141,128 @@ func init() {
 }
 
 func initConfig() {
-	// Configuration initialization will be implemented later
+	path := cfgFile
+	explicit := path != ""
+	if !explicit {
+		path = internal.DefaultConfigPath()
+	}
+
+	loaded, err := internal.LoadConfig(path, explicit)
+	if err != nil {
+		color.New(color.FgRed, color.Bold).Printf("❌ %v\n", err)
+		exitWithConfigError()
+	}
+	config = loaded
 }
 
 func setupGlobals(cmd *cobra.Command) {
 	// Commands that don't require inventory file
 	commandsWithoutInventory := map[string]bool{
-		"scan":    true,
-		"version": true,
-		"help":    true,
+		"scan":     true,
+		"resume":   true,
+		"last":     true,
+		"history":  true,
+		"changes":  true,
+		"snapshot": true,
+		"version":  true,
+		"help":     true,
 	}
 
 	// Validate protocol
 	if protocol != "ssh" && protocol != "http" {
 		color.New(color.FgRed, color.Bold).Printf("❌ Invalid protocol: %s. Must be 'ssh' or 'http'\n", protocol)
-		os.Exit(1)
+		exitWithConfigError()
+	}
+
+	// Validate output format and route the human-readable UI to stderr in structured modes
+	if err := internal.ValidateOutputFormat(outputFormat); err != nil {
+		color.New(color.FgRed, color.Bold).Printf("❌ %v\n", err)
+		exitWithConfigError()
 	}
+	emitter = internal.NewEmitter(outputFormat, cmd.Name(), os.Stdout)
+	if emitter.Structured() {
+		os.Stdout = os.Stderr
+		color.Output = os.Stderr
+	}
+
+	// Use plain output when requested or when the UI is not going to a terminal
+	internal.SetPlainOutput(internal.DetectPlainOutput(os.Stdout, plainMode, noColor))
+
+	// A dry run never rewrites an older inventory or tracker on disk
+	internal.SetPersistMigrations(!dryRun)
+
+	// Configure retries for network operations
+	if retries < 0 {
+		color.New(color.FgRed, color.Bold).Printf("❌ Invalid --retries: %d. Must not be negative\n", retries)
+		exitWithConfigError()
+	}
+	if retryDelay < 0 {
+		color.New(color.FgRed, color.Bold).Printf("❌ Invalid --retry-delay: %s. Must not be negative\n", retryDelay)
+		exitWithConfigError()
+	}
+	if retryJitter < 0 || retryJitter > 1 {
+		color.New(color.FgRed, color.Bold).Printf("❌ Invalid --retry-jitter: %g. Must be between 0 and 1\n", retryJitter)
+		exitWithConfigError()
+	}
+	internal.SetRetryPolicy(internal.RetryPolicy{
+		Retries:   retries,
+		BaseDelay: retryDelay,
+		Jitter:    retryJitter,
+	})
+
+	// Configure timeouts for network operations
+	internal.SetTimeouts(internal.Timeouts{
+		Clone:    cloneTimeout,
+		Fetch:    fetchTimeout,
+		Adaptive: adaptiveTimeouts,
+	})
+
+	// Configure per-host limits: config file first, command-line flags win
+	limits := internal.HostLimits{
+		PerHost:           make(map[string]int),
+		RequestsPerMinute: config.RequestsPerMinute,
+	}
+	for host, limit := range config.HostLimits {
+		limits.PerHost[host] = limit
+	}
+	for host, limit := range hostLimits {
+		limits.PerHost[host] = limit
+	}
+	if cmd.Flags().Changed("rate-limit") {
+		limits.RequestsPerMinute = requestsPerMinute
+	}
+	internal.SetHostLimits(limits)
 
 	// Handle output directory logic
 	setupOutputDirectory()
 
+	// Open the structured log file (every git command is recorded there)
+	setupLogFile(cmd)
+
 	// Check if inventory file exists (skip for commands that don't need it)
-	cmdName := cmd.Name()
-	if !commandsWithoutInventory[cmdName] {
+	// Subcommands share the requirement of their top-level command
+	top := cmd
+	for top.HasParent() && top.Parent().HasParent() {
+		top = top.Parent()
+	}
+	if !commandsWithoutInventory[top.Name()] {
 		if _, err := os.Stat(file); os.IsNotExist(err) {
 			color.New(color.FgRed, color.Bold).Printf("❌ Inventory file not found: %s\n", file)
 			color.New(color.FgYellow).Println("💡 Tip: Create a projects-inventory.json file or specify a different file with --file")
-			os.Exit(1)
+			emitter.Error("Inventory file not found: %s", file)
+			exitWithConfigError()
+		}
+
+		// Upgrade an older tracker once, before any command reads it, and refuse newer ones
+		backupPath, err := internal.MigrateTracker(directory)
+		var versionErr *internal.SchemaVersionError
+		if errors.As(err, &versionErr) {
+			color.New(color.FgRed, color.Bold).Printf("❌ %v\n", err)
+			emitter.Error("%v", err)
+			exitWithConfigError()
+		} else if backupPath != "" {
+			color.New(color.FgCyan).Printf("ℹ️  Migrated the tracker to schema version %d (backup: %s)\n", internal.TrackerSchemaVersion, backupPath)
+			internal.FileLog().Info("tracker migrated", "to", internal.TrackerSchemaVersion, "backup", backupPath)
 		}
 	}
 }
@@ -145,4 +305,52 @@ func setupOutputDirectory() {
 // GetOutputDirectory returns the current output directory (for use by commands)
 func GetOutputDirectory() string {
 	return directory
-}
\ No newline at end of file
+}
+
+// setupLogFile opens the rotating log file: config file first, command-line flags win
+func setupLogFile(cmd *cobra.Command) {
+	path, level, format := config.LogFile, config.LogLevel, config.LogFormat
+	maxSize, maxBackups := config.LogMaxSizeMB, config.LogMaxBackups
+	if cmd.Flags().Changed("log-file") || path == "" {
+		path = logFile
+	}
+	if cmd.Flags().Changed("log-level") || level == "" {
+		level = logLevel
+	}
+	if cmd.Flags().Changed("log-format") || format == "" {
+		format = logFormat
+	}
+	if cmd.Flags().Changed("log-max-size") || maxSize == 0 {
+		maxSize = logMaxSize
+	}
+	if cmd.Flags().Changed("log-max-backups") || maxBackups == 0 {
+		maxBackups = logMaxBackups
+	}
+
+	parsedLevel, err := internal.ParseLogLevel(level)
+	if err != nil {
+		color.New(color.FgRed, color.Bold).Printf("❌ %v\n", err)
+		exitWithConfigError()
+	}
+
+	// The default log lives in the workspace and is only written once the output directory exists
+	explicit := path != ""
+	if !explicit {
+		path = filepath.Join(directory, internal.DefaultLogFileName)
+	}
+
+	err = internal.OpenLogFile(internal.LogFileOptions{
+		Path:       path,
+		Level:      parsedLevel,
+		Format:     format,
+		MaxSize:    int64(maxSize) * 1024 * 1024,
+		MaxBackups: maxBackups,
+		CreateDir:  explicit,
+	})
+	if err != nil {
+		color.New(color.FgRed, color.Bold).Printf("❌ %v\n", err)
+		exitWithConfigError()
+	}
+
+	internal.FileLog().Info("run started", "command", cmd.CommandPath(), "args", os.Args[1:], "version", Version)
+}
@@ -15,9 +15,12 @@ import (
 )
 
 var (
-	scanParallel int
-	scanMaxDepth int
-	scanShowClean bool
+	scanParallel      int
+	scanMaxDepth      int
+	scanShowClean     bool
+	scanFailOnChanges bool
+	scanReportFile    string
+	scanJUnitFile     string
 )
 
 // scanCmd represents the scan command
@@ -50,6 +53,9 @@ func init() {
 	scanCmd.Flags().IntVarP(&scanParallel, "parallel", "p", 10, "Number of parallel scan operations (1-20)")
 	scanCmd.Flags().IntVarP(&scanMaxDepth, "max-depth", "d", 10, "Maximum directory depth to scan (default: 10)")
 	scanCmd.Flags().BoolVarP(&scanShowClean, "show-clean", "c", false, "Show clean repositories in results")
+	scanCmd.Flags().BoolVar(&scanFailOnChanges, "fail-on-changes", false, "Exit with code 3 when any repository has uncommitted changes")
+	scanCmd.Flags().StringVar(&scanReportFile, "report", "", "Write an HTML (.html) or Markdown (.md) report to this file")
+	scanCmd.Flags().StringVar(&scanJUnitFile, "junit", "", "Write a JUnit XML report with one test case per repository to this file")
 }
 
 func runScan(cmd *cobra.Command, args []string) {
@@ -59,6 +65,10 @@ func runScan(cmd *cobra.Command, args []string) {
 	// Show banner
 	logger.Banner()
 
+	if !validateReportPath(scanReportFile, logger) {
+		return
+	}
+
 	// Determine scan directory
 	scanDir := "."
 	if len(args) > 0 {
@@ -69,12 +79,14 @@ func runScan(cmd *cobra.Command, args []string) {
 	absDir, err := filepath.Abs(scanDir)
 	if err != nil {
 		logger.Error("Failed to get absolute path for %s: %v", scanDir, err)
+		setExitCode(ExitConfigError)
 		return
 	}
 
 	// Verify directory exists
 	if _, err := os.Stat(absDir); os.IsNotExist(err) {
 		logger.Error("Directory does not exist: %s", absDir)
+		setExitCode(ExitConfigError)
 		return
 	}
 
@@ -104,7 +116,9 @@ func runScan(cmd *cobra.Command, args []string) {
 	results := scanRepositoriesForChanges(repositories, logger)
 
 	// Display results
-	displayScanResults(results, logger, time.Since(startTime).String())
+	summary := displayScanResults(results, logger, time.Since(startTime).String())
+	writeReport(scanReportFile, scanReport(absDir, results, summary), logger)
+	writeJUnit(scanJUnitFile, scanJUnit(results), startTime, logger)
 }
 
 // discoverGitRepositories recursively finds all git repositories in a directory
@@ -159,14 +173,14 @@ func discoverGitRepositories(rootDir string, maxDepth int) []string {
 
 // ScanResult represents the result of scanning a repository
 type ScanResult struct {
-	Path             string
-	Name             string
-	HasChanges       bool
-	ModifiedFiles    int
-	StagedFiles      int
-	UntrackedFiles   int
-	Branch           string
-	Error            string
+	Path           string `json:"path"`
+	Name           string `json:"name"`
+	HasChanges     bool   `json:"has_changes"`
+	ModifiedFiles  int    `json:"modified_files"`
+	StagedFiles    int    `json:"staged_files"`
+	UntrackedFiles int    `json:"untracked_files"`
+	Branch         string `json:"branch"`
+	Error          string `json:"error,omitempty"`
 }
 
 // scanRepositoriesForChanges checks all repositories for uncommitted changes
@@ -176,8 +190,7 @@ func scanRepositoriesForChanges(repositories []string, logger *internal.Logger)
 	var wg sync.WaitGroup
 
 	// Create progress bar
-	bar := progressbar.NewOptions(len(repositories),
-		progressbar.OptionSetDescription("🔎 Scanning repositories"),
+	bar := internal.NewProgressBar(len(repositories), "🔎 Scanning repositories",
 		progressbar.OptionSetWidth(50),
 		progressbar.OptionShowCount(),
 		progressbar.OptionShowIts(),
@@ -208,6 +221,7 @@ func scanRepositoriesForChanges(repositories []string, logger *internal.Logger)
 
 		mutex.Lock()
 		results = append(results, result)
+		emitter.Result(result)
 		bar.Add(1)
 		mutex.Unlock()
 	}
@@ -257,7 +271,7 @@ func scanRepository(repoPath string) ScanResult {
 }
 
 // displayScanResults shows the scan results in a formatted way
-func displayScanResults(results []ScanResult, logger *internal.Logger, duration string) {
+func displayScanResults(results []ScanResult, logger *internal.Logger, duration string) ChangesSummary {
 	// Group results
 	var cleanRepos []ScanResult
 	var modifiedRepos []ScanResult
@@ -283,6 +297,18 @@ func displayScanResults(results []ScanResult, logger *internal.Logger, duration
 		}
 	}
 
+	summary := ChangesSummary{
+		Total:     len(results),
+		Clean:     len(cleanRepos),
+		Modified:  len(modifiedRepos),
+		Staged:    len(stagedRepos),
+		Untracked: len(untrackedRepos),
+		Errors:    len(errorRepos),
+		Duration:  duration,
+	}
+	emitter.Summary(summary)
+	setExitCodeForChanges(summary, scanFailOnChanges)
+
 	// Display summary
 	logger.Header("📊 Scan Results Summary")
 
@@ -377,4 +403,5 @@ func displayScanResults(results []ScanResult, logger *internal.Logger, duration
 		logger.Info("💡 Tip: Review and commit your changes before syncing")
 		logger.Info("💡 Use --verbose or -v to see full paths")
 	}
+	return summary
 }
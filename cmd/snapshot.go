@@ -0,0 +1,396 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"olive-clone-assistant-v2/internal"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var snapshotOnBranch bool
+
+// snapshotCmd groups the commands that record and restore the exact commits of a workspace
+var snapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "📸 Save, restore and compare the exact commits of every repository",
+	Long: color.New(color.FgBlue, color.Bold).Sprint(`
+📸 Snapshot Command
+===================
+
+A snapshot is a lock file of the workspace: the URL, branch and exact commit
+of every tracked repository. Use it to reproduce a workspace for bisecting a
+cross-repository bug, or to give a new team member the same state as yours.
+`),
+}
+
+// snapshotSaveCmd records the commit of every tracked repository
+var snapshotSaveCmd = &cobra.Command{
+	Use:   "save <file>",
+	Short: "💾 Record the branch and commit of every tracked repository",
+	Args:  cobra.ExactArgs(1),
+	Run:   runSnapshotSave,
+}
+
+// snapshotRestoreCmd brings the workspace back to a snapshot
+var snapshotRestoreCmd = &cobra.Command{
+	Use:   "restore <file>",
+	Short: "⏪ Clone missing repositories and check out the commits of a snapshot",
+	Long: color.New(color.FgBlue, color.Bold).Sprint(`
+⏪ Snapshot Restore
+==================
+
+Clone the repositories of the snapshot that are missing and check out the
+recorded commit in every repository.
+
+• 📌 Commits are checked out as a detached HEAD by default
+• 🌿 --on-branch resets the recorded branch to the commit instead
+• 🛡️  Repositories with uncommitted changes or an unfinished merge/rebase are
+     left alone, as are branches with commits that are on no remote
+• 👀 --dry-run lists the changes without making them
+`),
+	Args: cobra.ExactArgs(1),
+	Run:  runSnapshotRestore,
+}
+
+// snapshotDiffCmd compares two snapshots
+var snapshotDiffCmd = &cobra.Command{
+	Use:   "diff <a> <b>",
+	Short: "🔍 List the repositories and commit ranges that differ between two snapshots",
+	Args:  cobra.ExactArgs(2),
+	Run:   runSnapshotDiff,
+}
+
+func init() {
+	rootCmd.AddCommand(snapshotCmd)
+	snapshotCmd.AddCommand(snapshotSaveCmd, snapshotRestoreCmd, snapshotDiffCmd)
+
+	snapshotRestoreCmd.Flags().BoolVar(&snapshotOnBranch, "on-branch", false, "Reset the recorded branch to the commit instead of detaching HEAD")
+}
+
+// SnapshotSaveSummary is the structured output of snapshot save
+type SnapshotSaveSummary struct {
+	File     string            `json:"file"`
+	Projects int               `json:"projects"`
+	Skipped  map[string]string `json:"skipped,omitempty"` // Project path → reason
+}
+
+// SnapshotRestoreResult is the outcome for one repository of a snapshot
+type SnapshotRestoreResult struct {
+	internal.SnapshotProject
+	LocalPath string   `json:"local_path"`
+	Action    string   `json:"action"` // "cloned", "checked-out", "unchanged", "refused", "failed" or "would-…"
+	LocalWork []string `json:"local_work,omitempty"`
+	Error     string   `json:"error,omitempty"`
+}
+
+// SnapshotRestoreSummary is the structured output of snapshot restore
+type SnapshotRestoreSummary struct {
+	Total      int                     `json:"total"`
+	Cloned     int                     `json:"cloned"`
+	CheckedOut int                     `json:"checked_out"`
+	Unchanged  int                     `json:"unchanged"`
+	Refused    int                     `json:"refused"`
+	Failed     int                     `json:"failed"`
+	DryRun     bool                    `json:"dry_run"`
+	Results    []SnapshotRestoreResult `json:"results"`
+}
+
+// SnapshotDiffSummary is the structured output of snapshot diff
+type SnapshotDiffSummary struct {
+	Added       int                           `json:"added"`
+	Removed     int                           `json:"removed"`
+	Changed     int                           `json:"changed"`
+	Differences []internal.SnapshotDifference `json:"differences"`
+}
+
+func runSnapshotSave(cmd *cobra.Command, args []string) {
+	logger := internal.NewLogger(verbose)
+
+	absDir, err := filepath.Abs(directory)
+	if err != nil {
+		logger.Error("Failed to get absolute path for %s: %v", directory, err)
+		setExitCode(ExitConfigError)
+		return
+	}
+	if _, err := os.Stat(filepath.Join(absDir, internal.TrackingFileName)); err != nil {
+		logger.Error("No tracker in %s; clone the repositories first", absDir)
+		emitter.Error("No tracker in %s", absDir)
+		setExitCode(ExitConfigError)
+		return
+	}
+	tracker, err := internal.LoadOrCreateTracker(absDir, file)
+	if err != nil {
+		logger.Error("Failed to read tracker: %v", err)
+		emitter.Error("Failed to read tracker: %v", err)
+		setExitCode(ExitConfigError)
+		return
+	}
+
+	snapshot := internal.NewSnapshot(absDir)
+	summary := SnapshotSaveSummary{File: args[0], Skipped: make(map[string]string)}
+	for _, tracked := range tracker.Projects {
+		if tracked.Status == "orphaned" {
+			continue
+		}
+		if !internal.IsGitRepository(tracked.LocalPath) {
+			summary.Skipped[relativeTo(absDir, tracked.LocalPath)] = "not on disk"
+			continue
+		}
+		project, err := internal.SnapshotTrackedProject(tracked, absDir)
+		if err != nil {
+			summary.Skipped[relativeTo(absDir, tracked.LocalPath)] = err.Error()
+			continue
+		}
+		snapshot.Projects = append(snapshot.Projects, project)
+	}
+	summary.Projects = len(snapshot.Projects)
+
+	for path, reason := range summary.Skipped {
+		logger.Warning("Skipped %s: %s", path, reason)
+	}
+	if err := internal.SaveSnapshot(args[0], snapshot); err != nil {
+		logger.Error("%v", err)
+		emitter.Error("%v", err)
+		setExitCode(ExitPartialFailure)
+		return
+	}
+	logger.Success("Saved the commits of %d repositories to %s", summary.Projects, args[0])
+	emitter.Summary(summary)
+}
+
+func runSnapshotRestore(cmd *cobra.Command, args []string) {
+	logger := internal.NewLogger(verbose)
+
+	snapshot, ok := loadSnapshotFile(args[0], logger)
+	if !ok {
+		return
+	}
+
+	absDir, err := internal.EnsureOutputDirectory(directory, logger)
+	if err != nil {
+		logger.Error("Output directory setup failed: %v", err)
+		emitter.Error("Output directory setup failed: %v", err)
+		setExitCode(ExitConfigError)
+		return
+	}
+
+	tracker, err := internal.LoadOrCreateTracker(absDir, file)
+	if err != nil {
+		logger.Warning("Could not read tracker, restoring to the snapshot paths: %v", err)
+		tracker = nil
+	}
+
+	summary := SnapshotRestoreSummary{Total: len(snapshot.Projects), DryRun: dryRun, Results: []SnapshotRestoreResult{}}
+	fmt.Println()
+	for _, project := range snapshot.Projects {
+		info := internal.ProjectInfo{ID: project.ID, Name: project.Name, URL: project.URL, Group: project.Group,
+			GitURL: internal.FormatGitURL(project.URL, protocol), LocalPath: internal.SnapshotProjectPath(project, absDir)}
+		if tracker != nil {
+			if i := internal.FindTrackedProject(tracker, info); i >= 0 && internal.IsGitRepository(tracker.Projects[i].LocalPath) {
+				info.LocalPath = tracker.Projects[i].LocalPath
+				info.GitURL = tracker.Projects[i].GitURL
+			}
+		}
+
+		result := restoreSnapshotProject(project, info)
+		switch result.Action {
+		case "cloned":
+			summary.Cloned++
+			color.New(color.FgGreen).Printf("📥 %s: cloned at %s\n", project.Name, shortHash(project.Commit))
+		case "would-clone":
+			summary.Cloned++
+			logger.DryRun("Would clone %s to %s at %s", project.Name, relativeTo(absDir, result.LocalPath), shortHash(project.Commit))
+		case "checked-out":
+			summary.CheckedOut++
+			color.New(color.FgGreen).Printf("✅ %s: %s\n", project.Name, describeSnapshotCheckout(project))
+		case "would-check-out":
+			summary.CheckedOut++
+			logger.DryRun("Would leave %s %s", project.Name, describeSnapshotCheckout(project))
+		case "unchanged":
+			summary.Unchanged++
+			if verbose {
+				color.New(color.FgWhite).Printf("⏭️  %s: already at %s\n", project.Name, shortHash(project.Commit))
+			}
+		case "refused":
+			summary.Refused++
+			color.New(color.FgYellow).Printf("🛡️  %s: kept, %s\n", project.Name, strings.Join(result.LocalWork, ", "))
+		case "failed":
+			summary.Failed++
+			color.New(color.FgRed).Printf("❌ %s: %s\n", project.Name, result.Error)
+		}
+
+		if tracker != nil && (result.Action == "cloned" || result.Action == "checked-out") {
+			internal.UpdateTrackedProject(tracker, info, result.Action, project.Commit)
+		}
+		summary.Results = append(summary.Results, result)
+	}
+
+	if tracker != nil && !dryRun && summary.Cloned+summary.CheckedOut > 0 {
+		if err := internal.SaveTracker(tracker); err != nil {
+			logger.Warning("Failed to update tracker: %v", err)
+		}
+	}
+
+	fmt.Println()
+	if dryRun {
+		logger.Info("%d would be cloned, %d checked out, %d are already at their commit", summary.Cloned, summary.CheckedOut, summary.Unchanged)
+	} else {
+		logger.Success("%d cloned, %d checked out, %d already at their commit", summary.Cloned, summary.CheckedOut, summary.Unchanged)
+	}
+	if summary.Refused > 0 {
+		logger.Warning("%d repositories with local work were kept", summary.Refused)
+		setExitCode(ExitDirtyRepos)
+	}
+	if summary.Failed > 0 {
+		setExitCode(ExitPartialFailure)
+	}
+	emitter.Summary(summary)
+}
+
+// restoreSnapshotProject clones or checks out one repository of a snapshot
+func restoreSnapshotProject(project internal.SnapshotProject, info internal.ProjectInfo) SnapshotRestoreResult {
+	result := SnapshotRestoreResult{SnapshotProject: project, LocalPath: info.LocalPath}
+	branch := ""
+	if snapshotOnBranch {
+		branch = project.Branch
+	}
+
+	if !internal.IsGitRepository(info.LocalPath) {
+		if dryRun {
+			result.Action = "would-clone"
+			return result
+		}
+		clone := internal.CloneRepositorySilent(info.GitURL, info.LocalPath, 0)
+		if !clone.Success {
+			result.Action, result.Error = "failed", clone.Message
+			return result
+		}
+		if err := checkoutSnapshotCommit(info.LocalPath, project.Commit, branch); err != nil {
+			result.Action, result.Error = "failed", err.Error()
+			return result
+		}
+		result.Action = "cloned"
+		return result
+	}
+
+	head, _ := internal.GetCurrentCommitHash(info.LocalPath)
+	current, _ := internal.GetGitBranch(info.LocalPath)
+	if head == project.Commit && (branch == "" || current == branch) {
+		result.Action = "unchanged"
+		return result
+	}
+
+	if clean, files := isWorkingDirectoryClean(info.LocalPath); !clean {
+		result.LocalWork = append(result.LocalWork, fmt.Sprintf("%d uncommitted changes", files))
+	}
+	if operation := getOperationInProgress(info.LocalPath); operation != "" {
+		result.LocalWork = append(result.LocalWork, operation+" in progress")
+	}
+	if branch != "" {
+		if dropped := internal.CommitsDroppedFromBranch(info.LocalPath, branch, project.Commit); dropped > 0 {
+			result.LocalWork = append(result.LocalWork, fmt.Sprintf("%d commits on %s that are on no remote", dropped, branch))
+		}
+	}
+	if len(result.LocalWork) > 0 {
+		result.Action = "refused"
+		return result
+	}
+
+	if dryRun {
+		result.Action = "would-check-out"
+		return result
+	}
+	if err := checkoutSnapshotCommit(info.LocalPath, project.Commit, branch); err != nil {
+		result.Action, result.Error = "failed", err.Error()
+		return result
+	}
+	result.Action = "checked-out"
+	internal.FileLog().Info("snapshot restored", "project", project.Name, "path", info.LocalPath, "from", head, "to", project.Commit, "branch", branch)
+	return result
+}
+
+// checkoutSnapshotCommit fetches the commit if needed and checks it out
+func checkoutSnapshotCommit(localPath, commit, branch string) error {
+	if err := internal.EnsureCommit(localPath, commit, 0); err != nil {
+		return err
+	}
+	return internal.CheckoutCommit(localPath, commit, branch)
+}
+
+// describeSnapshotCheckout says where a restored repository was left
+func describeSnapshotCheckout(project internal.SnapshotProject) string {
+	if snapshotOnBranch && project.Branch != "" {
+		return fmt.Sprintf("%s reset to %s", project.Branch, shortHash(project.Commit))
+	}
+	return fmt.Sprintf("detached at %s", shortHash(project.Commit))
+}
+
+func runSnapshotDiff(cmd *cobra.Command, args []string) {
+	logger := internal.NewLogger(verbose)
+
+	a, ok := loadSnapshotFile(args[0], logger)
+	if !ok {
+		return
+	}
+	b, ok := loadSnapshotFile(args[1], logger)
+	if !ok {
+		return
+	}
+
+	summary := SnapshotDiffSummary{Differences: internal.DiffSnapshots(a, b)}
+	logger.Header(fmt.Sprintf("🔍 %s → %s", args[0], args[1]))
+	fmt.Println()
+	for _, difference := range summary.Differences {
+		switch difference.Kind {
+		case internal.SnapshotAdded:
+			summary.Added++
+			color.New(color.FgGreen).Printf("   ➕ %s (%s)  at %s\n", difference.Project, difference.Group, shortHash(difference.ToCommit))
+		case internal.SnapshotRemoved:
+			summary.Removed++
+			color.New(color.FgRed).Printf("   ➖ %s (%s)  was at %s\n", difference.Project, difference.Group, shortHash(difference.FromCommit))
+		case internal.SnapshotChanged:
+			summary.Changed++
+			line := fmt.Sprintf("   🔄 %s (%s)  %s..%s", difference.Project, difference.Group, shortHash(difference.FromCommit), shortHash(difference.ToCommit))
+			if difference.FromCommit == difference.ToCommit {
+				line = fmt.Sprintf("   🔄 %s (%s)  at %s", difference.Project, difference.Group, shortHash(difference.ToCommit))
+			}
+			if difference.FromBranch != difference.ToBranch {
+				line += fmt.Sprintf("  (branch %s → %s)", describeSnapshotBranch(difference.FromBranch), describeSnapshotBranch(difference.ToBranch))
+			}
+			color.New(color.FgYellow).Println(line)
+		}
+	}
+
+	if len(summary.Differences) == 0 {
+		logger.Success("Both snapshots have every repository at the same commit")
+	} else {
+		fmt.Println()
+		color.New(color.FgCyan).Printf("   %d changed, %d added, %d removed\n", summary.Changed, summary.Added, summary.Removed)
+	}
+	emitter.Summary(summary)
+}
+
+// describeSnapshotBranch names the branch of a snapshot entry
+func describeSnapshotBranch(branch string) string {
+	if branch == "" {
+		return "detached"
+	}
+	return branch
+}
+
+// loadSnapshotFile reads a snapshot, reporting errors as configuration errors
+func loadSnapshotFile(path string, logger *internal.Logger) (*internal.Snapshot, bool) {
+	snapshot, err := internal.LoadSnapshot(path)
+	if err != nil {
+		logger.Error("%v", err)
+		emitter.Error("%v", err)
+		setExitCode(ExitConfigError)
+		return nil, false
+	}
+	return snapshot, true
+}
@@ -3,9 +3,10 @@ package cmd
 import (
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
+	"sync"
+	"time"
 
 	"olive-clone-assistant-v2/internal"
 
@@ -33,40 +34,149 @@ This command provides detailed information about:
 	Run: runStatus,
 }
 
+var (
+	statusParallel    int
+	statusFetch       bool
+	statusFailOn      []string
+	statusReportFile  string
+	statusJUnitFile   string
+	statusMetricsFile string
+)
+
+// statusFailOnConditions maps each --fail-on condition to its exit code
+var statusFailOnConditions = map[string]int{
+	"missing": ExitPartialFailure,
+	"empty":   ExitPartialFailure,
+	"dirty":   ExitDirtyRepos,
+	"behind":  ExitPartialFailure,
+	"ahead":   ExitPartialFailure,
+
+	// Local work that a pull or re-clone could lose
+	"stash":        ExitDirtyRepos,
+	"unpushed":     ExitDirtyRepos,
+	"in-progress":  ExitDirtyRepos,
+	"detached":     ExitPartialFailure,
+	"wrong-branch": ExitPartialFailure,
+	"remote-drift": ExitPartialFailure,
+}
+
 func init() {
 	rootCmd.AddCommand(statusCmd)
+
+	statusCmd.Flags().IntVarP(&statusParallel, "parallel", "p", 10, "Number of repositories checked in parallel (1-20)")
+	statusCmd.Flags().BoolVar(&statusFetch, "fetch", false, "Fetch from the remote first so ahead/behind counts are current (slower)")
+	statusCmd.Flags().StringSliceVar(&statusFailOn, "fail-on", nil, "Exit non-zero when repositories match any condition (missing, empty, dirty, behind, ahead, stash, unpushed, in-progress, detached, wrong-branch, remote-drift)")
+	statusCmd.Flags().StringVar(&statusReportFile, "report", "", "Write an HTML (.html) or Markdown (.md) report to this file")
+	statusCmd.Flags().StringVar(&statusJUnitFile, "junit", "", "Write a JUnit XML report with one test case per repository to this file")
+	statusCmd.Flags().StringVar(&statusMetricsFile, "metrics-file", "", "Write Prometheus textfile-collector metrics to this file")
 }
 
 type RepoStatus struct {
-	Project     internal.ProjectInfo
-	Exists      bool
-	IsGitRepo   bool
-	IsClean     bool
-	Branch      string
-	Ahead       int
-	Behind      int
-	Uncommitted int
-	Error       string
+	Project     internal.ProjectInfo `json:"project"`
+	Exists      bool                 `json:"exists"`
+	IsGitRepo   bool                 `json:"is_git_repo"`
+	IsEmpty     bool                 `json:"is_empty"`
+	IsClean     bool                 `json:"is_clean"`
+	Branch      string               `json:"branch"`
+	Ahead       int                  `json:"ahead"`
+	Behind      int                  `json:"behind"`
+	Uncommitted int                  `json:"uncommitted"`
+	Error       string               `json:"error,omitempty"`
+
+	// Last local commit on HEAD
+	Commit           string `json:"commit,omitempty"`
+	LastCommitDate   string `json:"last_commit_date,omitempty"`
+	LastCommitAuthor string `json:"last_commit_author,omitempty"`
+
+	// Local work that is not on the remote
+	Stashes          int              `json:"stashes"`
+	UnpushedBranches []UnpushedBranch `json:"unpushed_branches,omitempty"`
+
+	Detached       bool   `json:"detached"`
+	Operation      string `json:"operation,omitempty"`       // merge, rebase, cherry-pick or revert in progress
+	ExpectedBranch string `json:"expected_branch,omitempty"` // Branch configured in the inventory
+	WrongBranch    bool   `json:"wrong_branch"`
+
+	// origin compared with the inventory URL
+	RemoteURL         string `json:"remote_url,omitempty"`
+	ExpectedRemoteURL string `json:"expected_remote_url,omitempty"`
+	RemoteDrift       string `json:"remote_drift,omitempty"` // "url" or "protocol"
+}
+
+// UnpushedBranch is a local branch, other than the checked-out one, with commits that are not on its upstream
+// Branches without an upstream count the commits that are on no remote at all
+type UnpushedBranch struct {
+	Name       string `json:"name"`
+	Ahead      int    `json:"ahead"`
+	NoUpstream bool   `json:"no_upstream"`
+}
+
+// StatusSummary represents the counts shown in the status summary
+type StatusSummary struct {
+	Total       int `json:"total"`
+	Clean       int `json:"clean"`
+	Missing     int `json:"missing"`
+	Empty       int `json:"empty"`
+	Dirty       int `json:"dirty"`
+	Behind      int `json:"behind"`
+	Ahead       int `json:"ahead"`
+	NotGitRepos int `json:"not_git_repos"`
+	FetchErrors int `json:"fetch_errors,omitempty"`
+	Stashed     int `json:"stashed"`
+	Unpushed    int `json:"unpushed"`
+	InProgress  int `json:"in_progress"`
+	Detached    int `json:"detached"`
+	WrongBranch int `json:"wrong_branch"`
+	RemoteDrift int `json:"remote_drift"`
 }
 
 func runStatus(cmd *cobra.Command, args []string) {
 	logger := internal.NewLogger(verbose)
+	startTime := time.Now()
 
 	// Show banner
 	logger.Banner()
 
+	if !validateReportPath(statusReportFile, logger) {
+		return
+	}
+
+	if statusParallel < 1 || statusParallel > 20 {
+		logger.Error("Parallel value must be between 1 and 20")
+		emitter.Error("Parallel value must be between 1 and 20")
+		setExitCode(ExitConfigError)
+		return
+	}
+
+	for _, condition := range statusFailOn {
+		if _, ok := statusFailOnConditions[condition]; !ok {
+			logger.Error("Invalid --fail-on condition: %s. Must be one of missing, empty, dirty, behind, ahead, stash, unpushed, in-progress, detached, wrong-branch, remote-drift", condition)
+			emitter.Error("Invalid --fail-on condition: %s", condition)
+			setExitCode(ExitConfigError)
+			return
+		}
+	}
+
 	// Load inventory
 	logger.Header("📋 Loading Project Inventory")
 	inventory, err := internal.LoadInventory(file)
 	if err != nil {
 		logger.Error("Failed to load inventory: %v", err)
+		emitter.Error("Failed to load inventory: %v", err)
+		setExitCode(ExitConfigError)
 		return
 	}
 
+	// Use physical location as default directory if not specified via flags
+	if directory == "" && inventory.PhysicalLocation != "" {
+		directory = inventory.PhysicalLocation
+	}
+
 	// Collect all projects
 	allProjects := internal.CollectAllProjects(*inventory)
 	if len(allProjects) == 0 {
 		logger.Warning("No projects found in inventory")
+		setExitCode(ExitConfigError)
 		return
 	}
 
@@ -75,6 +185,7 @@ func runStatus(cmd *cobra.Command, args []string) {
 		filteredProjects := internal.FilterProjectsByGroup(allProjects, groupFilter)
 		if len(filteredProjects) == 0 {
 			logger.Warning("No projects found for group: %s", groupFilter)
+			setExitCode(ExitConfigError)
 			return
 		}
 		allProjects = filteredProjects
@@ -84,6 +195,7 @@ func runStatus(cmd *cobra.Command, args []string) {
 	absDir, err := filepath.Abs(directory)
 	if err != nil {
 		logger.Error("Failed to get absolute path for %s: %v", directory, err)
+		setExitCode(ExitConfigError)
 		return
 	}
 
@@ -92,21 +204,15 @@ func runStatus(cmd *cobra.Command, args []string) {
 	color.New(color.FgCyan).Printf("Checking %d repositories...\n", len(allProjects))
 	fmt.Println()
 
-	// Prepare projects with full paths
-	var projectsWithPaths []internal.ProjectInfo
-	for _, project := range allProjects {
-		gitURL := internal.FormatGitURL(project.URL, protocol)
-		dirPath := internal.ExtractDirectoryPath(project.URL)
-		localPath := filepath.Join(absDir, dirPath)
-
-		projectInfo := internal.ProjectInfo{
-			Name:      project.Name,
-			URL:       project.URL,
-			GitURL:    gitURL,
-			LocalPath: localPath,
-			Group:     project.Group,
-		}
-		projectsWithPaths = append(projectsWithPaths, projectInfo)
+	// Resolve paths the way clone does, preferring where the tracker says a project was cloned
+	tracker, err := internal.LoadOrCreateTracker(absDir, file)
+	if err != nil {
+		logger.Warning("Could not read tracker, using inventory paths: %v", err)
+		tracker = nil
+	}
+	projectsWithPaths := resolveStatusPaths(allProjects, absDir, tracker)
+	if statusFetch {
+		projectsWithPaths = internal.ApplyTimeouts(projectsWithPaths, tracker)
 	}
 
 	// Check status of all repositories
@@ -114,14 +220,43 @@ func runStatus(cmd *cobra.Command, args []string) {
 
 	// Display results
 	displayStatusResults(statuses, logger)
+	writeReport(statusReportFile, statusReport(absDir, statuses, time.Since(startTime).String()), logger)
+	writeJUnit(statusJUnitFile, statusJUnit(statuses), startTime, logger)
+	writeMetrics(statusMetricsFile, statusMetrics(statusMetricsFile, absDir, statuses, time.Since(startTime)), logger)
+}
+
+// resolveStatusPaths sets the git URL and local path of each project
+// The tracker's recorded path wins, so repositories cloned elsewhere are not reported missing
+func resolveStatusPaths(projects []internal.ProjectInfo, absDir string, tracker *internal.ProjectTracker) []internal.ProjectInfo {
+	trackedPaths := make(map[string]string)
+	if tracker != nil {
+		for identity, index := range internal.MatchTrackedProjects(tracker, projects) {
+			if localPath := tracker.Projects[index].LocalPath; localPath != "" {
+				trackedPaths[identity] = localPath
+			}
+		}
+	}
+
+	resolved := make([]internal.ProjectInfo, 0, len(projects))
+	for _, project := range projects {
+		project.GitURL = internal.FormatGitURL(project.URL, protocol)
+		if localPath, ok := trackedPaths[internal.ProjectIdentity(project)]; ok {
+			project.LocalPath = localPath
+		} else {
+			project.LocalPath = internal.CreateProjectLocalPath(absDir, project.URL, project.Group)
+		}
+		resolved = append(resolved, project)
+	}
+	return resolved
 }
 
 func checkAllRepositories(projects []internal.ProjectInfo, logger *internal.Logger) []RepoStatus {
-	var statuses []RepoStatus
+	statuses := make([]RepoStatus, len(projects))
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
 
 	// Create progress bar
-	bar := progressbar.NewOptions(len(projects),
-		progressbar.OptionSetDescription("Checking status..."),
+	bar := internal.NewProgressBar(len(projects), "Checking status...",
 		progressbar.OptionSetWidth(50),
 		progressbar.OptionShowCount(),
 		progressbar.OptionSetTheme(progressbar.Theme{
@@ -133,12 +268,30 @@ func checkAllRepositories(projects []internal.ProjectInfo, logger *internal.Logg
 		}),
 	)
 
-	for _, project := range projects {
+	// Create semaphore for parallel processing
+	semaphore := make(chan struct{}, statusParallel)
+
+	// Process function; results keep the inventory order
+	processProject := func(index int, project internal.ProjectInfo) {
+		defer wg.Done()
+		semaphore <- struct{}{}
+		defer func() { <-semaphore }()
+
 		status := checkRepositoryStatus(project)
-		statuses = append(statuses, status)
+
+		mutex.Lock()
+		statuses[index] = status
+		emitter.Result(status)
 		bar.Add(1)
+		mutex.Unlock()
 	}
 
+	for i, project := range projects {
+		wg.Add(1)
+		go processProject(i, project)
+	}
+
+	wg.Wait()
 	bar.Finish()
 	fmt.Println()
 
@@ -162,10 +315,46 @@ func checkRepositoryStatus(project internal.ProjectInfo) RepoStatus {
 	}
 	status.IsGitRepo = true
 
-	// Get current branch
+	// A clone whose origin no longer matches the inventory keeps syncing the old location
+	if origin, err := internal.GetRemoteURL(project.LocalPath); err == nil && origin != "" {
+		status.RemoteURL = origin
+		if expected, drift := internal.CheckRemoteDrift(project, origin, driftProtocol()); drift != "" {
+			status.ExpectedRemoteURL = expected
+			status.RemoteDrift = drift
+		}
+	}
+
+	// An empty repository has no branch to compare or changes to report
+	if internal.IsEmptyRepository(project.LocalPath) {
+		status.IsEmpty = true
+		return status
+	}
+
+	// Update remote-tracking branches so ahead/behind reflect the remote
+	if statusFetch {
+		if err := internal.FetchRepository(project.LocalPath, project.FetchTimeout); err != nil {
+			status.Error = fmt.Sprintf("Fetch failed: %v", err)
+		}
+	}
+
+	// Get current branch; none means a detached HEAD
 	if branch, err := getGitBranch(project.LocalPath); err == nil {
 		status.Branch = branch
+		status.Detached = branch == ""
+	}
+	if project.Branch != "" && !status.Detached {
+		status.ExpectedBranch = project.Branch
+		status.WrongBranch = status.Branch != project.Branch
+	}
+
+	if commit, date, author, err := getLastCommit(project.LocalPath); err == nil {
+		status.Commit = commit
+		status.LastCommitDate = date
+		status.LastCommitAuthor = author
 	}
+	status.Operation = getOperationInProgress(project.LocalPath)
+	status.Stashes = getStashCount(project.LocalPath)
+	status.UnpushedBranches = getUnpushedBranches(project.LocalPath, status.Branch)
 
 	// Check if working directory is clean
 	if isClean, uncommitted := isWorkingDirectoryClean(project.LocalPath); isClean {
@@ -184,17 +373,109 @@ func checkRepositoryStatus(project internal.ProjectInfo) RepoStatus {
 }
 
 func getGitBranch(path string) (string, error) {
-	cmd := exec.Command("git", "-C", path, "branch", "--show-current")
-	output, err := cmd.Output()
+	output, err := internal.RunGitCommand("-C", path, "branch", "--show-current")
 	if err != nil {
 		return "", err
 	}
 	return strings.TrimSpace(string(output)), nil
 }
 
+// getLastCommit returns the short hash, committer date (RFC 3339) and author of HEAD
+func getLastCommit(path string) (string, string, string, error) {
+	output, err := internal.RunGitCommand("-C", path, "log", "-1", "--format=%h%x00%cI%x00%an")
+	if err != nil {
+		return "", "", "", err
+	}
+	parts := strings.Split(strings.TrimSpace(string(output)), "\x00")
+	if len(parts) != 3 {
+		return "", "", "", fmt.Errorf("unexpected output format")
+	}
+	return parts[0], parts[1], parts[2], nil
+}
+
+// getOperationInProgress detects an unfinished merge, rebase, cherry-pick or revert
+func getOperationInProgress(path string) string {
+	output, err := internal.RunGitCommand("-C", path, "rev-parse", "--absolute-git-dir")
+	if err != nil {
+		return ""
+	}
+	gitDir := strings.TrimSpace(string(output))
+
+	markers := []struct{ file, operation string }{
+		{"rebase-merge", "rebase"},
+		{"rebase-apply", "rebase"},
+		{"MERGE_HEAD", "merge"},
+		{"CHERRY_PICK_HEAD", "cherry-pick"},
+		{"REVERT_HEAD", "revert"},
+	}
+	for _, marker := range markers {
+		if _, err := os.Stat(filepath.Join(gitDir, marker.file)); err == nil {
+			return marker.operation
+		}
+	}
+	return ""
+}
+
+func getStashCount(path string) int {
+	output, err := internal.RunGitCommand("-C", path, "stash", "list", "--format=%gd")
+	if err != nil {
+		return 0
+	}
+	trimmed := strings.TrimSpace(string(output))
+	if trimmed == "" {
+		return 0
+	}
+	return len(strings.Split(trimmed, "\n"))
+}
+
+// getUnpushedBranches lists local branches ahead of their upstream, or without an upstream
+// and with commits that are on no remote. The current branch is covered by Ahead instead,
+// unless its upstream is missing or gone
+func getUnpushedBranches(path, currentBranch string) []UnpushedBranch {
+	output, err := internal.RunGitCommand("-C", path, "for-each-ref",
+		"--format=%(refname:short)%00%(upstream:short)%00%(upstream:track)", "refs/heads")
+	if err != nil {
+		return nil
+	}
+
+	var branches []UnpushedBranch
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		parts := strings.Split(line, "\x00")
+		if len(parts) != 3 || parts[0] == "" {
+			continue
+		}
+		name, upstream, track := parts[0], parts[1], parts[2]
+		if name == currentBranch && upstream != "" && track != "[gone]" {
+			continue
+		}
+
+		// A deleted upstream ("[gone]") leaves the branch as unprotected as having none
+		if upstream == "" || track == "[gone]" {
+			count, err := internal.RunGitCommand("-C", path, "rev-list", "--count", name, "--not", "--remotes")
+			if err != nil {
+				continue
+			}
+			var ahead int
+			fmt.Sscanf(strings.TrimSpace(string(count)), "%d", &ahead)
+			if ahead > 0 {
+				branches = append(branches, UnpushedBranch{Name: name, Ahead: ahead, NoUpstream: true})
+			}
+			continue
+		}
+
+		var ahead int
+		if i := strings.Index(track, "ahead "); i >= 0 {
+			fmt.Sscanf(track[i+len("ahead "):], "%d", &ahead)
+		}
+		if ahead > 0 {
+			branches = append(branches, UnpushedBranch{Name: name, Ahead: ahead})
+		}
+	}
+	return branches
+}
+
 func isWorkingDirectoryClean(path string) (bool, int) {
-	cmd := exec.Command("git", "-C", path, "status", "--porcelain")
-	output, err := cmd.Output()
+	output, err := internal.RunGitCommand("-C", path, "status", "--porcelain")
 	if err != nil {
 		return false, 0
 	}
@@ -208,8 +489,7 @@ func isWorkingDirectoryClean(path string) (bool, int) {
 }
 
 func getAheadBehindCount(path string) (int, int, error) {
-	cmd := exec.Command("git", "-C", path, "rev-list", "--left-right", "--count", "HEAD...@{upstream}")
-	output, err := cmd.Output()
+	output, err := internal.RunGitCommand("-C", path, "rev-list", "--left-right", "--count", "HEAD...@{upstream}")
 	if err != nil {
 		return 0, 0, err
 	}
@@ -230,55 +510,129 @@ func displayStatusResults(statuses []RepoStatus, logger *internal.Logger) {
 	// Group by status
 	var missing []RepoStatus
 	var notGitRepos []RepoStatus
+	var empty []RepoStatus
 	var clean []RepoStatus
 	var dirty []RepoStatus
 	var needsPull []RepoStatus
 	var needsPush []RepoStatus
+	var fetchFailed []RepoStatus
+	var stashed []RepoStatus
+	var unpushed []RepoStatus
+	var inProgress []RepoStatus
+	var detached []RepoStatus
+	var wrongBranch []RepoStatus
+	var remoteDrift []RepoStatus
 
 	for _, status := range statuses {
-		if !status.Exists {
+		if status.Error != "" {
+			fetchFailed = append(fetchFailed, status)
+		}
+		if status.Stashes > 0 {
+			stashed = append(stashed, status)
+		}
+		if len(status.UnpushedBranches) > 0 {
+			unpushed = append(unpushed, status)
+		}
+		if status.Operation != "" {
+			inProgress = append(inProgress, status)
+		}
+		if status.Detached {
+			detached = append(detached, status)
+		}
+		if status.WrongBranch {
+			wrongBranch = append(wrongBranch, status)
+		}
+		if status.RemoteDrift != "" {
+			remoteDrift = append(remoteDrift, status)
+		}
+
+		// A repository can be dirty, behind and ahead at the same time
+		switch {
+		case !status.Exists:
 			missing = append(missing, status)
-		} else if !status.IsGitRepo {
+		case !status.IsGitRepo:
 			notGitRepos = append(notGitRepos, status)
-		} else if !status.IsClean {
-			dirty = append(dirty, status)
-		} else if status.Behind > 0 {
-			needsPull = append(needsPull, status)
-		} else if status.Ahead > 0 {
-			needsPush = append(needsPush, status)
-		} else {
-			clean = append(clean, status)
+		case status.IsEmpty:
+			empty = append(empty, status)
+		default:
+			if !status.IsClean {
+				dirty = append(dirty, status)
+			}
+			if status.Behind > 0 {
+				needsPull = append(needsPull, status)
+			}
+			if status.Ahead > 0 {
+				needsPush = append(needsPush, status)
+			}
+			if isCleanStatus(status) {
+				clean = append(clean, status)
+			}
 		}
 	}
 
+	summary := summarizeStatuses(statuses)
+	emitter.Summary(summary)
+	setExitCodeForStatus(summary)
+
 	// Display summary
 	logger.Header("📊 Status Summary")
 	
-	total := len(statuses)
-	color.New(color.FgWhite, color.Bold).Printf("Total repositories: %d\n", total)
+	color.New(color.FgWhite, color.Bold).Printf("Total repositories: %d\n", summary.Total)
 	
-	if len(clean) > 0 {
-		color.New(color.FgGreen, color.Bold).Printf("✅ Clean: %d\n", len(clean))
+	if summary.Clean > 0 {
+		color.New(color.FgGreen, color.Bold).Printf("✅ Clean: %d\n", summary.Clean)
 	}
 	
-	if len(missing) > 0 {
-		color.New(color.FgRed, color.Bold).Printf("❌ Missing: %d\n", len(missing))
+	if summary.Missing > 0 {
+		color.New(color.FgRed, color.Bold).Printf("❌ Missing: %d\n", summary.Missing)
 	}
 	
-	if len(dirty) > 0 {
-		color.New(color.FgYellow, color.Bold).Printf("📝 Uncommitted changes: %d\n", len(dirty))
+	if summary.Empty > 0 {
+		color.New(color.FgYellow, color.Bold).Printf("📭 Empty: %d\n", summary.Empty)
 	}
 	
-	if len(needsPull) > 0 {
-		color.New(color.FgBlue, color.Bold).Printf("⬇️  Need pull: %d\n", len(needsPull))
+	if summary.Dirty > 0 {
+		color.New(color.FgYellow, color.Bold).Printf("📝 Uncommitted changes: %d\n", summary.Dirty)
 	}
 	
-	if len(needsPush) > 0 {
-		color.New(color.FgMagenta, color.Bold).Printf("⬆️  Need push: %d\n", len(needsPush))
+	if summary.Behind > 0 {
+		color.New(color.FgBlue, color.Bold).Printf("⬇️  Need pull: %d\n", summary.Behind)
+	}
+	
+	if summary.Ahead > 0 {
+		color.New(color.FgMagenta, color.Bold).Printf("⬆️  Need push: %d\n", summary.Ahead)
 	}
 	
-	if len(notGitRepos) > 0 {
-		color.New(color.FgRed, color.Bold).Printf("⚠️  Not git repos: %d\n", len(notGitRepos))
+	if summary.NotGitRepos > 0 {
+		color.New(color.FgRed, color.Bold).Printf("⚠️  Not git repos: %d\n", summary.NotGitRepos)
+	}
+
+	if summary.FetchErrors > 0 {
+		color.New(color.FgRed, color.Bold).Printf("⚠️  Fetch failed: %d\n", summary.FetchErrors)
+	}
+
+	if summary.InProgress > 0 {
+		color.New(color.FgRed, color.Bold).Printf("🔀 Merge/rebase in progress: %d\n", summary.InProgress)
+	}
+
+	if summary.Stashed > 0 {
+		color.New(color.FgYellow, color.Bold).Printf("📦 With stashes: %d\n", summary.Stashed)
+	}
+
+	if summary.Unpushed > 0 {
+		color.New(color.FgMagenta, color.Bold).Printf("⬆️  With unpushed branches: %d\n", summary.Unpushed)
+	}
+
+	if summary.Detached > 0 {
+		color.New(color.FgYellow, color.Bold).Printf("📌 Detached HEAD: %d\n", summary.Detached)
+	}
+
+	if summary.WrongBranch > 0 {
+		color.New(color.FgYellow, color.Bold).Printf("🌿 Not on inventory branch: %d\n", summary.WrongBranch)
+	}
+
+	if summary.RemoteDrift > 0 {
+		color.New(color.FgYellow, color.Bold).Printf("🔗 Remote URL drift: %d\n", summary.RemoteDrift)
 	}
 
 	// Show detailed information for problematic repos
@@ -293,6 +647,14 @@ func displayStatusResults(statuses []RepoStatus, logger *internal.Logger) {
 		}
 	}
 
+	if len(empty) > 0 {
+		fmt.Println()
+		logger.Header("📭 Empty Repositories")
+		for _, status := range empty {
+			color.New(color.FgYellow).Printf("  • %s (%s)\n", status.Project.Name, status.Project.Group)
+		}
+	}
+
 	if len(dirty) > 0 {
 		fmt.Println()
 		logger.Header("📝 Repositories with Uncommitted Changes")
@@ -323,11 +685,174 @@ func displayStatusResults(statuses []RepoStatus, logger *internal.Logger) {
 		}
 	}
 
+	if len(fetchFailed) > 0 {
+		fmt.Println()
+		logger.Header("⚠️  Fetch Failed (ahead/behind may be stale)")
+		for _, status := range fetchFailed {
+			color.New(color.FgRed).Printf("  • %s: %s\n", status.Project.Name, status.Error)
+		}
+	}
+
+	if len(inProgress) > 0 {
+		fmt.Println()
+		logger.Header("🔀 Unfinished Merge or Rebase")
+		for _, status := range inProgress {
+			color.New(color.FgRed).Printf("  • %s - %s in progress\n", status.Project.Name, status.Operation)
+			if verbose {
+				color.New(color.FgWhite, color.Faint).Printf("    Path: %s\n", status.Project.LocalPath)
+			}
+		}
+	}
+
+	if len(stashed) > 0 {
+		fmt.Println()
+		logger.Header("📦 Repositories with Stashes")
+		for _, status := range stashed {
+			color.New(color.FgYellow).Printf("  • %s (%d stashes)\n", status.Project.Name, status.Stashes)
+		}
+	}
+
+	if len(unpushed) > 0 {
+		fmt.Println()
+		logger.Header("⬆️  Local Branches Not Pushed")
+		for _, status := range unpushed {
+			color.New(color.FgMagenta).Printf("  • %s\n", status.Project.Name)
+			for _, branch := range status.UnpushedBranches {
+				if branch.NoUpstream {
+					color.New(color.FgWhite, color.Faint).Printf("    %s: %d commits, no upstream\n", branch.Name, branch.Ahead)
+				} else {
+					color.New(color.FgWhite, color.Faint).Printf("    %s: %d commits ahead\n", branch.Name, branch.Ahead)
+				}
+			}
+		}
+	}
+
+	if len(detached) > 0 {
+		fmt.Println()
+		logger.Header("📌 Detached HEAD")
+		for _, status := range detached {
+			color.New(color.FgYellow).Printf("  • %s - at %s\n", status.Project.Name, status.Commit)
+		}
+	}
+
+	if len(wrongBranch) > 0 {
+		fmt.Println()
+		logger.Header("🌿 Not on Inventory Branch")
+		for _, status := range wrongBranch {
+			color.New(color.FgYellow).Printf("  • %s - on %s, expected %s\n", status.Project.Name, status.Branch, status.ExpectedBranch)
+		}
+	}
+
+	if len(remoteDrift) > 0 {
+		fmt.Println()
+		logger.Header("🔗 Remote URL Drift")
+		for _, status := range remoteDrift {
+			color.New(color.FgYellow).Printf("  • %s - origin is %s, expected %s\n", status.Project.Name, status.RemoteURL, status.ExpectedRemoteURL)
+		}
+		color.New(color.FgHiBlack).Println("  💡 Run 'syncx remotes fix' to update them")
+	}
+
 	if len(clean) > 0 && verbose {
 		fmt.Println()
 		logger.Header("✅ Clean Repositories")
 		for _, status := range clean {
 			color.New(color.FgGreen).Printf("  • %s - %s\n", status.Project.Name, status.Branch)
+			color.New(color.FgWhite, color.Faint).Printf("    Last commit %s on %s by %s\n", status.Commit, describeCommitDate(status.LastCommitDate), status.LastCommitAuthor)
+		}
+	}
+}
+
+// summarizeStatuses counts the repositories per condition. The console summary, the --report
+// file and the --fail-on exit code all use these counts.
+func summarizeStatuses(statuses []RepoStatus) StatusSummary {
+	summary := StatusSummary{Total: len(statuses)}
+	for _, status := range statuses {
+		if status.Error != "" {
+			summary.FetchErrors++
+		}
+		if status.Stashes > 0 {
+			summary.Stashed++
+		}
+		if len(status.UnpushedBranches) > 0 {
+			summary.Unpushed++
+		}
+		if status.Operation != "" {
+			summary.InProgress++
+		}
+		if status.Detached {
+			summary.Detached++
+		}
+		if status.WrongBranch {
+			summary.WrongBranch++
+		}
+		if status.RemoteDrift != "" {
+			summary.RemoteDrift++
+		}
+
+		switch {
+		case !status.Exists:
+			summary.Missing++
+		case !status.IsGitRepo:
+			summary.NotGitRepos++
+		case status.IsEmpty:
+			summary.Empty++
+		default:
+			if !status.IsClean {
+				summary.Dirty++
+			}
+			if status.Behind > 0 {
+				summary.Behind++
+			}
+			if status.Ahead > 0 {
+				summary.Ahead++
+			}
+			if isCleanStatus(status) {
+				summary.Clean++
+			}
 		}
 	}
-}
\ No newline at end of file
+	return summary
+}
+
+// isCleanStatus reports a repository with nothing to commit, pull or push and no local work at risk
+func isCleanStatus(status RepoStatus) bool {
+	return status.IsClean && status.Behind == 0 && status.Ahead == 0 && !hasLocalWorkAtRisk(status)
+}
+
+// hasLocalWorkAtRisk reports stashes, unpushed branches, unfinished operations or an unexpected HEAD
+func hasLocalWorkAtRisk(status RepoStatus) bool {
+	return status.Stashes > 0 || len(status.UnpushedBranches) > 0 || status.Operation != "" ||
+		status.Detached || status.WrongBranch
+}
+
+// describeCommitDate shortens an RFC 3339 commit date for display
+func describeCommitDate(date string) string {
+	t, err := time.Parse(time.RFC3339, date)
+	if err != nil {
+		return date
+	}
+	return t.Format("2006-01-02 15:04")
+}
+
+// setExitCodeForStatus applies the --fail-on conditions to the status counts
+func setExitCodeForStatus(summary StatusSummary) {
+	counts := map[string]int{
+		"missing": summary.Missing,
+		"empty":   summary.Empty,
+		"dirty":   summary.Dirty,
+		"behind":  summary.Behind,
+		"ahead":   summary.Ahead,
+
+		"stash":        summary.Stashed,
+		"unpushed":     summary.Unpushed,
+		"in-progress":  summary.InProgress,
+		"detached":     summary.Detached,
+		"wrong-branch": summary.WrongBranch,
+		"remote-drift": summary.RemoteDrift,
+	}
+	for _, condition := range statusFailOn {
+		if counts[condition] > 0 {
+			setExitCode(statusFailOnConditions[condition])
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"olive-clone-assistant-v2/internal"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var trackerMaxDepth int
+
+// trackerCmd groups the maintenance commands of the tracking file
+var trackerCmd = &cobra.Command{
+	Use:   "tracker",
+	Short: "🗂️  Maintain the tracking file of an output directory",
+	Long: color.New(color.FgBlue, color.Bold).Sprint(`
+🗂️  Tracker Command
+===================
+
+The tracker (.olive-clone-tracker.json) remembers which repositories were
+cloned where. Use its subcommands when it was deleted, corrupted or is out of date.
+`),
+}
+
+// trackerRebuildCmd regenerates the tracker from the repositories on disk
+var trackerRebuildCmd = &cobra.Command{
+	Use:   "rebuild",
+	Short: "🔧 Regenerate the tracker from the repositories on disk",
+	Long: color.New(color.FgBlue, color.Bold).Sprint(`
+🔧 Tracker Rebuild
+==================
+
+Walk the output directory, match each repository's origin URL against the
+inventory and write the tracker again with paths and commit hashes.
+
+• 📭 Lists inventory projects that are not on disk (the next clone fetches them)
+• ❓ Lists repositories on disk that are not in the inventory
+• 💾 Keeps a copy of the previous tracker in .olive-clone-tracker.json.bak
+`),
+	Run: runTrackerRebuild,
+}
+
+func init() {
+	rootCmd.AddCommand(trackerCmd)
+	trackerCmd.AddCommand(trackerRebuildCmd)
+
+	trackerRebuildCmd.Flags().IntVarP(&trackerMaxDepth, "max-depth", "d", 10, "Maximum directory depth to search for repositories")
+}
+
+func runTrackerRebuild(cmd *cobra.Command, args []string) {
+	logger := internal.NewLogger(verbose)
+
+	// Show banner
+	logger.Banner()
+
+	inventory, err := internal.LoadInventory(file)
+	if err != nil {
+		logger.Error("Failed to load inventory: %v", err)
+		emitter.Error("Failed to load inventory: %v", err)
+		setExitCode(ExitConfigError)
+		return
+	}
+
+	// Use physical location as default directory if not specified via flags
+	if directory == "" && inventory.PhysicalLocation != "" {
+		directory = inventory.PhysicalLocation
+	}
+
+	absDir, err := filepath.Abs(directory)
+	if err != nil {
+		logger.Error("Failed to get absolute path for %s: %v", directory, err)
+		setExitCode(ExitConfigError)
+		return
+	}
+	if info, err := os.Stat(absDir); err != nil || !info.IsDir() {
+		logger.Error("Output directory does not exist: %s", absDir)
+		emitter.Error("Output directory does not exist: %s", absDir)
+		setExitCode(ExitConfigError)
+		return
+	}
+
+	projects := internal.CollectAllProjects(*inventory)
+
+	tracker, err := internal.LoadOrCreateTracker(absDir, file)
+	if err != nil {
+		logger.Warning("Existing tracker is unreadable, rebuilding it from scratch: %v", err)
+		tracker = internal.NewTracker(absDir, file)
+	}
+	tracker.OutputDirectory = absDir
+	tracker.InventoryFile = file
+
+	spinner := logger.StartSpinner(fmt.Sprintf("Searching %s for git repositories...", absDir))
+	repoPaths := discoverGitRepositories(absDir, trackerMaxDepth)
+	logger.StopSpinnerSuccess(spinner, fmt.Sprintf("Found %d git repositories", len(repoPaths)))
+
+	rebuild := internal.RebuildTracker(tracker, projects, repoPaths, protocol)
+	if hash, err := internal.CalculateInventoryHash(file); err == nil {
+		tracker.InventoryHash = hash
+	}
+
+	displayTrackerRebuild(rebuild, absDir)
+	emitter.Summary(rebuild)
+
+	if dryRun {
+		logger.DryRun("Would write the tracker with %d projects to %s", len(tracker.Projects), filepath.Join(absDir, internal.TrackingFileName))
+		return
+	}
+
+	backupPath := filepath.Join(absDir, internal.TrackingFileName+".bak")
+	if backedUp, err := internal.BackupTracker(absDir, backupPath); err != nil {
+		logger.Error("%v", err)
+		setExitCode(ExitPartialFailure)
+		return
+	} else if backedUp {
+		logger.Info("Previous tracker saved to %s", backupPath)
+	}
+
+	if err := internal.SaveTracker(tracker); err != nil {
+		logger.Error("%v", err)
+		setExitCode(ExitPartialFailure)
+		return
+	}
+	internal.FileLog().Info("tracker rebuilt", "tracked", len(rebuild.Matched), "missing", len(rebuild.Missing), "untracked", len(rebuild.Untracked))
+	logger.Success("Tracker rebuilt with %d projects", len(tracker.Projects))
+}
+
+func displayTrackerRebuild(rebuild *internal.TrackerRebuild, absDir string) {
+	relative := func(path string) string {
+		if rel, err := filepath.Rel(absDir, path); err == nil {
+			return rel
+		}
+		return path
+	}
+
+	fmt.Println()
+	if len(rebuild.Matched) > 0 {
+		color.New(color.FgGreen, color.Bold).Printf("✅ Tracked (%d):\n", len(rebuild.Matched))
+		for _, tracked := range rebuild.Matched {
+			color.New(color.FgGreen).Printf("   %s → %s (%s)\n", tracked.Name, relative(tracked.LocalPath), shortHash(tracked.LastCommitHash))
+		}
+		fmt.Println()
+	}
+
+	if len(rebuild.Missing) > 0 {
+		color.New(color.FgYellow, color.Bold).Printf("📭 Not on disk (%d):\n", len(rebuild.Missing))
+		for _, project := range rebuild.Missing {
+			color.New(color.FgYellow).Printf("   %s (%s)\n", project.Name, project.Group)
+		}
+		color.New(color.FgHiBlack).Println("   💡 The next 'syncx clone' will clone them")
+		fmt.Println()
+	}
+
+	if len(rebuild.Untracked) > 0 {
+		color.New(color.FgMagenta, color.Bold).Printf("❓ Not in the inventory (%d):\n", len(rebuild.Untracked))
+		for _, repo := range rebuild.Untracked {
+			remote := repo.RemoteURL
+			if remote == "" {
+				remote = "no origin remote"
+			}
+			color.New(color.FgMagenta).Printf("   %s (%s)\n", relative(repo.Path), remote)
+		}
+		fmt.Println()
+	}
+
+	if len(rebuild.Removed) > 0 {
+		color.New(color.FgHiBlack, color.Bold).Printf("🗑️  Dropped stale entries (%d):\n", len(rebuild.Removed))
+		for _, tracked := range rebuild.Removed {
+			color.New(color.FgHiBlack).Printf("   %s (%s)\n", tracked.Name, relative(tracked.LocalPath))
+		}
+		fmt.Println()
+	}
+}
+
+// shortHash abbreviates a commit hash for display
+func shortHash(hash string) string {
+	if hash == "" {
+		return "no commits"
+	}
+	if len(hash) > 8 {
+		return hash[:8]
+	}
+	return hash
+}
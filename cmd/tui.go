@@ -0,0 +1,595 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"olive-clone-assistant-v2/internal"
+
+	"github.com/eiannone/keyboard"
+	"github.com/fatih/color"
+	"golang.org/x/term"
+)
+
+// Row states of the terminal UI
+const (
+	tuiPending = "pending"
+	tuiRunning = "running"
+	tuiDone    = "done"
+	tuiFailed  = "failed"
+	tuiEmpty   = "empty"
+	tuiSkipped = "skipped"
+)
+
+var (
+	tuiFilters = []string{"all", "active", "failed", "pending", "done"}
+	tuiSorts   = []string{"inventory", "name", "state", "elapsed"}
+
+	// Sort order of the "state" sort: problems first, finished work last
+	tuiStateOrder = map[string]int{tuiFailed: 0, tuiRunning: 1, tuiPending: 2, tuiEmpty: 3, tuiDone: 4, tuiSkipped: 5}
+)
+
+// tuiMaxOutputLines caps the git output kept per repository for the drill-down view
+const tuiMaxOutputLines = 500
+
+type tuiRow struct {
+	index     int
+	project   internal.ProjectInfo
+	state     string
+	operation string
+	started   time.Time
+	finished  time.Time
+	message   string
+	output    []string
+	result    *internal.OperationResult
+}
+
+func (r *tuiRow) elapsed() time.Duration {
+	switch {
+	case r.started.IsZero():
+		return 0
+	case r.state == tuiRunning:
+		return time.Since(r.started)
+	default:
+		return r.finished.Sub(r.started)
+	}
+}
+
+// tuiView holds the state of a terminal UI run; everything is guarded by mu
+type tuiView struct {
+	mu        sync.Mutex
+	title     string
+	rows      []*tuiRow
+	byPath    map[string]*tuiRow
+	queue     chan *tuiRow
+	running   int
+	stopping  bool
+	cursor    int
+	offset    int
+	filter    int
+	sortBy    int
+	detail    *tuiRow
+	scroll    int
+	startTime time.Time
+}
+
+// canRunTUI reports whether the terminal UI can take over the screen
+func canRunTUI() bool {
+	return !internal.PlainOutput() && outputFormat == "text" && term.IsTerminal(int(os.Stdout.Fd()))
+}
+
+// runTUI runs operation for every project in a full-screen table instead of a progress bar
+// The user can filter, sort, drill into a repository's git output, retry failures and skip repositories.
+// Results are returned in inventory order, without the skipped repositories.
+// An error means the terminal could not be set up and nothing was run.
+func runTUI(title string, projects []internal.ProjectInfo, workers int, operation func(internal.ProjectInfo) internal.OperationResult, journal *internal.RunJournal) ([]internal.OperationResult, int, error) {
+	keys, err := keyboard.GetKeys(16)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read the keyboard: %w", err)
+	}
+
+	view := &tuiView{
+		title:     title,
+		byPath:    make(map[string]*tuiRow),
+		queue:     make(chan *tuiRow, len(projects)),
+		startTime: time.Now(),
+	}
+	for i, project := range projects {
+		row := &tuiRow{index: i, project: project, state: tuiPending}
+		view.rows = append(view.rows, row)
+		view.byPath[filepath.Clean(project.LocalPath)] = row
+		view.queue <- row
+	}
+
+	var once sync.Once
+	restore := func() {
+		once.Do(func() {
+			fmt.Print("\x1b[?25h\x1b[?1049l")
+			keyboard.Close()
+		})
+	}
+	restoreTerminal = restore
+	defer func() {
+		restore()
+		restoreTerminal = nil
+	}()
+	fmt.Print("\x1b[?1049h\x1b[?25l")
+
+	internal.SetGitCommandObserver(view.observe)
+	defer internal.SetGitCommandObserver(nil)
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for row := range view.queue {
+				view.run(row, operation, journal)
+			}
+		}()
+	}
+
+	ticker := time.NewTicker(200 * time.Millisecond)
+	defer ticker.Stop()
+
+	view.render()
+	for !view.finished() {
+		select {
+		case event := <-keys:
+			if event.Key == keyboard.KeyCtrlC {
+				exitInterrupted()
+			}
+			view.handleKey(event)
+		case <-ticker.C:
+		}
+		view.render()
+	}
+
+	close(view.queue)
+	wg.Wait()
+
+	var results []internal.OperationResult
+	skipped := 0
+	for _, row := range view.rows {
+		if row.state == tuiSkipped {
+			skipped++
+			continue
+		}
+		if row.result != nil {
+			results = append(results, *row.result)
+		}
+	}
+	return results, skipped, nil
+}
+
+// run executes the operation of one row unless it was skipped while queued
+func (v *tuiView) run(row *tuiRow, operation func(internal.ProjectInfo) internal.OperationResult, journal *internal.RunJournal) {
+	v.mu.Lock()
+	if row.state != tuiPending {
+		v.mu.Unlock()
+		return
+	}
+	row.state = tuiRunning
+	row.started = time.Now()
+	row.operation = ""
+	row.message = ""
+	v.running++
+	v.mu.Unlock()
+
+	result := operation(row.project)
+	internal.MarkJournalJob(journal, result)
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.running--
+	row.finished = time.Now()
+	row.result = &result
+	row.message = result.Message
+	switch {
+	case result.Success:
+		row.state = tuiDone
+	case result.IsEmpty:
+		row.state = tuiEmpty
+	default:
+		row.state = tuiFailed
+	}
+	emitter.Result(result)
+	internal.LogOperationResult(result)
+}
+
+// observe records the git commands of each repository for the operation column and the drill-down view
+func (v *tuiView) observe(event internal.GitCommandEvent) {
+	path := internal.GitCommandPath(event.Args)
+	if path == "" {
+		return
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	row, ok := v.byPath[filepath.Clean(path)]
+	if !ok {
+		return
+	}
+
+	if !event.Done {
+		row.operation = internal.GitSubcommand(event.Args)
+		row.output = append(row.output, "$ git "+strings.Join(event.Args, " "))
+	} else {
+		for _, text := range []string{event.Stdout, event.Stderr} {
+			for _, line := range strings.Split(strings.TrimRight(text, "\n"), "\n") {
+				// Progress output rewrites the line with \r; keep the final state
+				if i := strings.LastIndex(line, "\r"); i >= 0 {
+					line = line[i+1:]
+				}
+				if line != "" {
+					row.output = append(row.output, "  "+line)
+				}
+			}
+		}
+		if event.Err != nil {
+			row.output = append(row.output, fmt.Sprintf("  ✗ %v (%s)", event.Err, event.Duration.Round(time.Millisecond)))
+		}
+	}
+	if len(row.output) > tuiMaxOutputLines {
+		row.output = row.output[len(row.output)-tuiMaxOutputLines:]
+	}
+}
+
+// finished reports whether the UI can close: the user asked to quit and no operation is still running
+func (v *tuiView) finished() bool {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.stopping && v.running == 0
+}
+
+// visible returns the rows that pass the current filter, in the current sort order
+func (v *tuiView) visible() []*tuiRow {
+	var rows []*tuiRow
+	for _, row := range v.rows {
+		switch tuiFilters[v.filter] {
+		case "active":
+			if row.state != tuiRunning && row.state != tuiPending {
+				continue
+			}
+		case "failed":
+			if row.state != tuiFailed {
+				continue
+			}
+		case "pending":
+			if row.state != tuiPending {
+				continue
+			}
+		case "done":
+			if row.state != tuiDone && row.state != tuiEmpty {
+				continue
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		switch tuiSorts[v.sortBy] {
+		case "name":
+			return rows[i].project.Name < rows[j].project.Name
+		case "state":
+			return tuiStateOrder[rows[i].state] < tuiStateOrder[rows[j].state]
+		case "elapsed":
+			return rows[i].elapsed() > rows[j].elapsed()
+		}
+		return rows[i].index < rows[j].index
+	})
+	return rows
+}
+
+// retry queues a failed or empty repository again
+func (v *tuiView) retry(row *tuiRow) {
+	if row.state != tuiFailed && row.state != tuiEmpty {
+		return
+	}
+	row.state = tuiPending
+	row.result = nil
+	row.message = "queued for retry"
+	row.output = append(row.output, "--- retry ---")
+	v.queue <- row
+}
+
+// skip drops a pending or failed repository from the run
+func (v *tuiView) skip(row *tuiRow) {
+	if row.state != tuiPending && row.state != tuiFailed {
+		return
+	}
+	row.state = tuiSkipped
+	row.result = nil
+	row.message = "skipped"
+}
+
+func (v *tuiView) handleKey(event keyboard.KeyEvent) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	rows := v.visible()
+	var selected *tuiRow
+	if v.detail != nil {
+		selected = v.detail
+	} else if v.cursor < len(rows) {
+		selected = rows[v.cursor]
+	}
+	_, height := terminalSize()
+	page := height - 5
+
+	switch {
+	case event.Key == keyboard.KeyEsc && v.detail != nil:
+		v.detail = nil
+	case event.Key == keyboard.KeyEnter && v.detail == nil && selected != nil:
+		v.detail = selected
+		v.scroll = len(selected.output)
+	case event.Key == keyboard.KeyArrowUp || event.Rune == 'k':
+		v.move(-1)
+	case event.Key == keyboard.KeyArrowDown || event.Rune == 'j':
+		v.move(1)
+	case event.Key == keyboard.KeyPgup:
+		v.move(-page)
+	case event.Key == keyboard.KeyPgdn:
+		v.move(page)
+	case event.Key == keyboard.KeyHome:
+		v.move(-len(v.rows) - tuiMaxOutputLines)
+	case event.Key == keyboard.KeyEnd:
+		v.move(len(v.rows) + tuiMaxOutputLines)
+	case event.Rune == 'f' && v.detail == nil:
+		v.filter = (v.filter + 1) % len(tuiFilters)
+		v.cursor, v.offset = 0, 0
+	case event.Rune == 's' && v.detail == nil:
+		v.sortBy = (v.sortBy + 1) % len(tuiSorts)
+	case event.Rune == 'r' && selected != nil && !v.stopping:
+		v.retry(selected)
+	case event.Rune == 'R' && !v.stopping:
+		for _, row := range v.rows {
+			if row.state == tuiFailed {
+				v.retry(row)
+			}
+		}
+	case event.Rune == 'x' && selected != nil:
+		v.skip(selected)
+	case event.Rune == 'q':
+		// Pending repositories are skipped; running ones finish before the UI closes
+		v.stopping = true
+		for _, row := range v.rows {
+			if row.state == tuiPending {
+				v.skip(row)
+			}
+		}
+	}
+}
+
+// move moves the cursor, or scrolls the output in the drill-down view
+func (v *tuiView) move(delta int) {
+	if v.detail != nil {
+		v.scroll = clamp(v.scroll+delta, 0, len(v.detail.output))
+		return
+	}
+	v.cursor = clamp(v.cursor+delta, 0, len(v.visible())-1)
+}
+
+func (v *tuiView) render() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	width, height := terminalSize()
+	var lines []string
+	if v.detail != nil {
+		lines = v.renderDetail(width, height)
+	} else {
+		lines = v.renderTable(width, height)
+	}
+
+	var b strings.Builder
+	b.WriteString("\x1b[H")
+	for _, line := range lines {
+		b.WriteString(line)
+		b.WriteString("\x1b[K\r\n")
+	}
+	b.WriteString("\x1b[J")
+	fmt.Print(b.String())
+}
+
+func (v *tuiView) renderHeader(width int) []string {
+	counts := make(map[string]int)
+	for _, row := range v.rows {
+		counts[row.state]++
+	}
+	finished := counts[tuiDone] + counts[tuiEmpty] + counts[tuiFailed]
+
+	status := fmt.Sprintf("%d/%d finished · %d running · %d failed · %d skipped · %s",
+		finished, len(v.rows)-counts[tuiSkipped], counts[tuiRunning], counts[tuiFailed], counts[tuiSkipped],
+		time.Since(v.startTime).Round(time.Second))
+	return []string{
+		color.New(color.Bold).Sprint(v.title) + "  " + fit(status, width-len(v.title)-4),
+		"",
+	}
+}
+
+func (v *tuiView) renderTable(width, height int) []string {
+	lines := v.renderHeader(width)
+
+	rows := v.visible()
+	v.cursor = clamp(v.cursor, 0, len(rows)-1)
+	visibleRows := height - 6
+	if visibleRows < 1 {
+		visibleRows = 1
+	}
+	if v.cursor < v.offset {
+		v.offset = v.cursor
+	}
+	if v.cursor >= v.offset+visibleRows {
+		v.offset = v.cursor - visibleRows + 1
+	}
+
+	nameWidth, groupWidth := 10, 5
+	for _, row := range v.rows {
+		nameWidth = max(nameWidth, len(row.project.Name))
+		groupWidth = max(groupWidth, len(row.project.Group))
+	}
+	nameWidth = min(nameWidth, max(10, width/3))
+	groupWidth = min(groupWidth, max(5, width/6))
+	messageWidth := width - (9 + nameWidth + groupWidth + 10 + 9 + 10)
+
+	format := fmt.Sprintf("  %%-8s %%-%ds %%-%ds %%-10s %%8s  %%s", nameWidth, groupWidth)
+	lines = append(lines, color.New(color.Faint).Sprintf(format, "STATE", "REPOSITORY", "GROUP", "OPERATION", "ELAPSED", "MESSAGE"))
+
+	for i := v.offset; i < len(rows) && i < v.offset+visibleRows; i++ {
+		row := rows[i]
+		elapsed := ""
+		if d := row.elapsed(); d >= time.Second {
+			elapsed = d.Round(100 * time.Millisecond).String()
+		} else if d > 0 {
+			elapsed = d.Round(time.Millisecond).String()
+		}
+		operation := row.operation
+		if row.state != tuiRunning {
+			operation = ""
+		}
+		line := fmt.Sprintf(format, row.state, fit(row.project.Name, nameWidth), fit(row.project.Group, groupWidth),
+			fit(operation, 10), elapsed, fit(oneLine(row.message), messageWidth))
+		line = fit(line, width)
+		if i == v.cursor {
+			lines = append(lines, color.New(color.ReverseVideo).Sprint(line))
+		} else {
+			lines = append(lines, tuiStateColor(row.state).Sprint(line))
+		}
+	}
+	if len(rows) == 0 {
+		lines = append(lines, color.New(color.Faint).Sprintf("  No repositories match the filter %q", tuiFilters[v.filter]))
+	}
+
+	for len(lines) < height-2 {
+		lines = append(lines, "")
+	}
+	lines = append(lines, color.New(color.Faint).Sprint(fit(fmt.Sprintf("filter: %s · sort: %s", tuiFilters[v.filter], tuiSorts[v.sortBy]), width)))
+	lines = append(lines, color.New(color.Faint).Sprint(fit(v.helpLine(), width)))
+	return lines
+}
+
+func (v *tuiView) renderDetail(width, height int) []string {
+	row := v.detail
+	lines := v.renderHeader(width)
+	lines = append(lines,
+		tuiStateColor(row.state).Sprint(fit(fmt.Sprintf("%s (%s) · %s · %s", row.project.Name, row.project.Group, row.state, row.project.LocalPath), width)),
+		fit(oneLine(row.message), width),
+		"",
+	)
+
+	outputRows := height - len(lines) - 1
+	if outputRows < 1 {
+		outputRows = 1
+	}
+	end := clamp(v.scroll, min(outputRows, len(row.output)), len(row.output))
+	start := max(0, end-outputRows)
+	for _, line := range row.output[start:end] {
+		lines = append(lines, fit(line, width))
+	}
+	if len(row.output) == 0 {
+		lines = append(lines, color.New(color.Faint).Sprint("  No git output yet"))
+	}
+
+	for len(lines) < height-1 {
+		lines = append(lines, "")
+	}
+	lines = append(lines, color.New(color.Faint).Sprint(fit("↑/↓ scroll · r retry · x skip · esc back", width)))
+	return lines
+}
+
+func (v *tuiView) helpLine() string {
+	if v.stopping {
+		return fmt.Sprintf("Waiting for %d running operation(s) to finish...", v.running)
+	}
+	done := true
+	for _, row := range v.rows {
+		if row.state == tuiPending || row.state == tuiRunning {
+			done = false
+		}
+	}
+	help := "↑/↓ move · enter output · f filter · s sort · r retry · R retry all · x skip · q quit"
+	if done {
+		help = "All operations finished · " + help
+	}
+	return help
+}
+
+func tuiStateColor(state string) *color.Color {
+	switch state {
+	case tuiRunning:
+		return color.New(color.FgCyan)
+	case tuiDone:
+		return color.New(color.FgGreen)
+	case tuiFailed:
+		return color.New(color.FgRed)
+	case tuiEmpty:
+		return color.New(color.FgYellow)
+	case tuiSkipped, tuiPending:
+		return color.New(color.Faint)
+	}
+	return color.New(color.Reset)
+}
+
+// terminalSize returns the size of the terminal, or 80x24 if it cannot be read
+func terminalSize() (int, int) {
+	width, height, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil || width <= 0 || height <= 0 {
+		return 80, 24
+	}
+	return width, height
+}
+
+// fit truncates s to width runes, marking the cut with an ellipsis
+func fit(s string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	runes := []rune(s)
+	if len(runes) <= width {
+		return s
+	}
+	if width == 1 {
+		return "…"
+	}
+	return string(runes[:width-1]) + "…"
+}
+
+// oneLine joins a multi-line message so it fits in a table cell
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func clamp(value, low, high int) int {
+	if value > high {
+		value = high
+	}
+	if value < low {
+		value = low
+	}
+	return value
+}
+
+// runWithTUI runs the operations in the terminal UI when --tui was given
+// ok is false when the caller should show its progress bar instead
+func runWithTUI(enabled bool, title string, projects []internal.ProjectInfo, workers int, operation func(internal.ProjectInfo) internal.OperationResult, journal *internal.RunJournal, logger *internal.Logger) ([]internal.OperationResult, int, bool) {
+	if !enabled {
+		return nil, 0, false
+	}
+	if !canRunTUI() {
+		logger.Warning("--tui needs an interactive terminal and text output, showing the progress bar instead")
+		return nil, 0, false
+	}
+
+	results, skipped, err := runTUI(title, projects, workers, operation, journal)
+	if err != nil {
+		logger.Warning("Terminal UI unavailable (%v), showing the progress bar instead", err)
+		return nil, 0, false
+	}
+	return results, skipped, true
+}
@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"olive-clone-assistant-v2/internal"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	uiParallel int
+	uiGroup    string
+)
+
+// uiCmd represents the ui command
+var uiCmd = &cobra.Command{
+	Use:   "ui",
+	Short: "🖥️  Sync every repository in a full-screen terminal UI",
+	Long: color.New(color.FgBlue, color.Bold).Sprint(`
+🖥️  UI Command
+==============
+
+Clone missing repositories and pull existing ones in a full-screen table
+that shows every repository's state, current git operation, elapsed time
+and last message while the run is in progress.
+
+• ↑/↓ (or j/k), PgUp/PgDn, Home/End to move
+• enter to see the repository's git output, esc to go back
+• f to cycle the filter, s to cycle the sort order
+• r to retry the selected failure, R to retry all failures
+• x to skip the selected repository, q to quit
+`),
+	Run: runUI,
+}
+
+func init() {
+	rootCmd.AddCommand(uiCmd)
+
+	uiCmd.Flags().IntVarP(&uiParallel, "parallel", "p", 10, "Number of parallel operations (1-20)")
+	uiCmd.Flags().StringVarP(&uiGroup, "group", "g", "", "Only sync repositories from specific group")
+}
+
+// uiOperation pulls a project that is already cloned and clones it otherwise
+func uiOperation(project internal.ProjectInfo) internal.OperationResult {
+	if internal.IsGitRepository(project.LocalPath) {
+		return pullOperation(project)
+	}
+	return cloneOperation(project)
+}
+
+func runUI(cmd *cobra.Command, args []string) {
+	logger := internal.NewLogger(verbose)
+	startTime := time.Now()
+
+	if !canRunTUI() {
+		logger.Error("The terminal UI needs an interactive terminal and text output")
+		emitter.Error("The terminal UI needs an interactive terminal and text output")
+		setExitCode(ExitConfigError)
+		return
+	}
+
+	if uiParallel < 1 || uiParallel > 20 {
+		logger.Error("Parallel value must be between 1 and 20")
+		setExitCode(ExitConfigError)
+		return
+	}
+
+	inventory, err := internal.LoadInventory(file)
+	if err != nil {
+		logger.Error("Failed to load inventory: %v", err)
+		emitter.Error("Failed to load inventory: %v", err)
+		setExitCode(ExitConfigError)
+		return
+	}
+
+	// Use physical location as default directory if not specified via flags
+	if directory == "" && inventory.PhysicalLocation != "" {
+		directory = inventory.PhysicalLocation
+	}
+
+	allProjects := internal.CollectAllProjects(*inventory)
+	if uiGroup != "" {
+		allProjects = internal.FilterProjectsByGroup(allProjects, uiGroup)
+	}
+	if len(allProjects) == 0 {
+		logger.Warning("No projects found in inventory")
+		setExitCode(ExitConfigError)
+		return
+	}
+
+	absDir, err := internal.EnsureOutputDirectory(directory, logger)
+	if err != nil {
+		logger.Error("Output directory setup failed: %v", err)
+		emitter.Error("Output directory setup failed: %v", err)
+		setExitCode(ExitConfigError)
+		return
+	}
+
+	// Resolve paths like status does, so repositories are found where the tracker says they were cloned
+	tracker, err := internal.LoadOrCreateTracker(absDir, file)
+	if err != nil {
+		logger.Warning("Could not read tracker, using inventory paths: %v", err)
+		tracker = nil
+	}
+	projects := applyProjectTimeouts(resolveStatusPaths(allProjects, absDir, tracker), absDir)
+
+	results, skipped, err := runTUI(fmt.Sprintf("🖥️  syncx · %s", absDir), projects, uiParallel, uiOperation, nil)
+	if err != nil {
+		logger.Error("Terminal UI unavailable: %v", err)
+		setExitCode(ExitConfigError)
+		return
+	}
+
+	summary := summarizeResults(results)
+	summary.TotalProjects = len(projects)
+	summary.SkippedCount = skipped
+	summary.TotalDuration = time.Since(startTime).String()
+	previous := recordLastRun(absDir, "ui", startTime, summary, logger)
+	recordHistory(absDir, "ui", startTime, summary, logger)
+	recordResultsInTracker(absDir, summary, logger)
+
+	logger.Summary(summary)
+	emitter.Summary(summary)
+	setExitCodeForSummary(summary)
+	notifyRun("ui", absDir, startTime, summary, previous, logger)
+}
+
+// summarizeResults counts the results of a run that mixes clones and pulls
+func summarizeResults(results []internal.OperationResult) internal.Summary {
+	var summary internal.Summary
+	for _, result := range results {
+		switch {
+		case result.Success:
+			summary.SuccessCount++
+			if result.IsClone {
+				summary.ClonedCount++
+			} else {
+				summary.UpdatedCount++
+			}
+			if result.Attempts > 1 {
+				summary.RetriedCount++
+				summary.RetriedProjects = append(summary.RetriedProjects, result.Project)
+			}
+		case result.IsEmpty:
+			summary.EmptyCount++
+			summary.EmptyProjects = append(summary.EmptyProjects, result.Project)
+		default:
+			summary.FailureCount++
+			summary.FailedProjects = append(summary.FailedProjects, result.Project)
+		}
+	}
+	summary.Results = results
+	return summary
+}
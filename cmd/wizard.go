@@ -0,0 +1,588 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"olive-clone-assistant-v2/internal"
+
+	"github.com/fatih/color"
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+var (
+	// wizardSelection holds the URLs of the projects picked in the wizard; nil when no wizard ran
+	wizardSelection map[string]bool
+	wizardOperation string
+)
+
+var errWizardCancelled = errors.New("wizard cancelled")
+
+// wizardCmd represents the wizard command
+var wizardCmd = &cobra.Command{
+	Use:   "wizard",
+	Short: "🧙 Pick repositories and options step by step, then clone or pull",
+	Long: color.New(color.FgBlue, color.Bold).Sprint(`
+🧙 Wizard Command
+=================
+
+Walk through a clone or pull run with prompts instead of flags:
+
+• 🚀 Quick Mode: every repository with the current defaults
+• 🎯 Custom Mode: tick groups and/or projects, choose protocol and parallelism
+• ⚙️  Advanced Mode: also the output directory, dry run and verbosity
+
+Every mode ends with a preview of what will run and asks for confirmation.
+'clone -i' and 'pull -i' start the same wizard for that command.
+`),
+	Run: runWizardCommand,
+}
+
+func init() {
+	rootCmd.AddCommand(wizardCmd)
+}
+
+func runWizardCommand(cmd *cobra.Command, args []string) {
+	if !runWizard("") {
+		return
+	}
+	if wizardOperation == "pull" {
+		runPull(cmd, args)
+	} else {
+		runClone(cmd, args)
+	}
+}
+
+// wizardTemplates render prompts like the rest of the CLI: an arrow on the active line and a check when chosen
+var wizardTemplates = &promptui.SelectTemplates{
+	Label:    "{{ . | bold }}",
+	Active:   "▶ {{ . | cyan }}",
+	Inactive: "  {{ . }}",
+	Selected: "✅ {{ . | green }}",
+}
+
+// checkboxTemplates explain that ENTER toggles items instead of moving on
+var checkboxTemplates = &promptui.SelectTemplates{
+	Label:    wizardTemplates.Label,
+	Active:   wizardTemplates.Active,
+	Inactive: wizardTemplates.Inactive,
+	Help:     `{{ "↑/↓ to move · ENTER toggles the checkbox, or runs the action under the cursor" | faint }}`,
+}
+
+// wizardOption is one line of a checkbox list: an item to tick, a heading or an action
+type wizardOption struct {
+	label  string
+	key    string // Group name or project URL; empty for headings and actions
+	action string // continue, clear, all, back or cancel
+}
+
+// wizard holds the answers collected so far
+type wizard struct {
+	projects  []internal.ProjectInfo
+	groups    []string
+	operation string
+	mode      string
+	groupSel  map[string]bool
+	projSel   map[string]bool
+	protocol  string
+	parallel  int
+	directory string
+	dryRun    bool
+	verbose   bool
+}
+
+// runWizard asks for the projects and options of a clone or pull run and applies the answers to the
+// command-line settings; operation is empty when the wizard should ask for it too.
+// It returns false when nothing should run.
+func runWizard(operation string) bool {
+	logger := internal.NewLogger(verbose)
+
+	if internal.PlainOutput() || outputFormat != "text" || !term.IsTerminal(int(os.Stdin.Fd())) {
+		logger.Error("The wizard needs an interactive terminal and text output")
+		emitter.Error("The wizard needs an interactive terminal and text output")
+		setExitCode(ExitConfigError)
+		return false
+	}
+
+	inventory, err := internal.LoadInventory(file)
+	if err != nil {
+		logger.Error("Failed to load inventory: %v", err)
+		emitter.Error("Failed to load inventory: %v", err)
+		setExitCode(ExitConfigError)
+		return false
+	}
+	projects := internal.CollectAllProjects(*inventory)
+	if len(projects) == 0 {
+		logger.Warning("No projects found in inventory")
+		setExitCode(ExitConfigError)
+		return false
+	}
+
+	w := &wizard{
+		projects:  projects,
+		groups:    internal.GetUniqueGroups(projects),
+		operation: operation,
+		groupSel:  make(map[string]bool),
+		projSel:   make(map[string]bool),
+		protocol:  protocol,
+		parallel:  parallel,
+		directory: directory,
+		dryRun:    dryRun,
+		verbose:   verbose,
+	}
+	if operation == "pull" {
+		w.parallel = pullParallel
+	}
+
+	color.New(color.FgCyan, color.Bold).Println("🧙 Welcome to the SyncX Wizard!")
+	color.New(color.FgCyan).Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Printf("Found %d projects across %d groups in %s\n\n", len(projects), len(w.groups), file)
+
+	if err := w.run(); err != nil {
+		fmt.Println()
+		if errors.Is(err, promptui.ErrInterrupt) || errors.Is(err, promptui.ErrEOF) {
+			logger.Warning("Wizard interrupted, nothing was run")
+			setExitCode(ExitInterrupted)
+		} else if errors.Is(err, errWizardCancelled) {
+			logger.Warning("Wizard cancelled, nothing was run")
+		} else {
+			logger.Error("Wizard failed: %v", err)
+			setExitCode(ExitConfigError)
+		}
+		return false
+	}
+
+	// Apply the answers as if they had been given as flags
+	wizardOperation = w.operation
+	wizardSelection = w.selectedURLs()
+	protocol = w.protocol
+	directory = w.directory
+	dryRun = w.dryRun
+	verbose = w.verbose
+	if w.operation == "pull" {
+		pullParallel = w.parallel
+	} else {
+		parallel = w.parallel
+	}
+
+	fmt.Println()
+	color.New(color.FgGreen, color.Bold).Println("🎯 Wizard Complete!")
+	fmt.Printf("   Selected %d projects · %s · protocol %s · parallel %d\n\n", len(wizardSelection), w.operation, w.protocol, w.parallel)
+	return true
+}
+
+func (w *wizard) run() error {
+	if w.operation == "" {
+		choice, err := wizardChoice("🧭 What would you like to do?", []string{
+			"📥 Clone - Clone repositories that are not on disk yet",
+			"🔄 Pull - Update repositories that are already cloned",
+		})
+		if err != nil {
+			return err
+		}
+		w.operation = []string{"clone", "pull"}[choice]
+	}
+
+	for {
+		choice, err := wizardChoice("🚀 Choose your operation mode", []string{
+			fmt.Sprintf("🚀 Quick Mode - %s all repositories with smart defaults", map[string]string{"clone": "Clone", "pull": "Pull"}[w.operation]),
+			"🎯 Custom Mode - Select specific projects and groups",
+			"⚙️  Advanced Mode - Full control over all options",
+			"❌ Cancel",
+		})
+		if err != nil {
+			return err
+		}
+
+		switch choice {
+		case 0:
+			w.mode = "quick"
+			for _, project := range w.projects {
+				w.projSel[project.URL] = true
+			}
+			if err := w.chooseProtocol(); err != nil {
+				return err
+			}
+			return w.confirm("📋 Quick Mode Preview", "❌ No - Cancel and exit")
+		case 1, 2:
+			w.mode = map[int]string{1: "custom", 2: "advanced"}[choice]
+			back, err := w.selectProjects()
+			if err != nil {
+				return err
+			}
+			if back {
+				continue
+			}
+			return w.configure()
+		default:
+			return errWizardCancelled
+		}
+	}
+}
+
+// configure asks for the run options of the custom and advanced modes and confirms them
+func (w *wizard) configure() error {
+	for {
+		if err := w.chooseProtocol(); err != nil {
+			return err
+		}
+		if err := w.chooseParallel(); err != nil {
+			return err
+		}
+		if w.mode == "custom" {
+			return w.confirm("📋 Selection Preview", "❌ No - Cancel and exit")
+		}
+
+		if err := w.chooseAdvanced(); err != nil {
+			return err
+		}
+		err := w.confirm("⚙️  Advanced Configuration Preview", "↩️  No - Go back and modify")
+		if !errors.Is(err, errWizardCancelled) {
+			return err
+		}
+	}
+}
+
+// selectProjects asks how to select repositories and runs the checkbox lists; back means return to the mode choice
+func (w *wizard) selectProjects() (bool, error) {
+	for {
+		choice, err := wizardChoice("How would you like to select repositories?", []string{
+			"📁 By Groups - Select entire project groups",
+			"📦 Individual Projects - Pick specific repositories",
+			"🔀 Mixed - Groups first, then individual projects",
+			"◀️  Back to previous step",
+		})
+		if err != nil {
+			return false, err
+		}
+
+		var action string
+		switch choice {
+		case 0:
+			action, err = w.selectGroups()
+		case 1:
+			action, err = w.selectIndividual(w.projects, false)
+		case 2:
+			action, err = w.selectGroups()
+			if err == nil && action == "continue" {
+				remaining := w.unselectedProjects()
+				if len(remaining) > 0 {
+					var more int
+					more, err = wizardChoice(fmt.Sprintf("Add additional projects? (%d remaining)", len(remaining)), []string{
+						"✅ Yes - Select additional individual projects",
+						"❌ No - Continue with group selections only",
+					})
+					if err == nil && more == 0 {
+						action, err = w.selectIndividual(remaining, true)
+					}
+				}
+			}
+		default:
+			return true, nil
+		}
+		if err != nil {
+			return false, err
+		}
+
+		switch action {
+		case "continue":
+			return false, nil
+		case "cancel":
+			return false, errWizardCancelled
+		}
+	}
+}
+
+func (w *wizard) selectGroups() (string, error) {
+	counts := make(map[string]int)
+	for _, project := range w.projects {
+		counts[project.Group]++
+	}
+
+	var options []wizardOption
+	for _, group := range w.groups {
+		options = append(options, wizardOption{label: fmt.Sprintf("%s (%d projects)", group, counts[group]), key: group})
+	}
+	return checkboxSelect("📁 Select groups (ENTER toggles)", "group", options, w.groupSel, false)
+}
+
+// selectIndividual shows projects grouped under headings; additional means groups were already picked
+func (w *wizard) selectIndividual(projects []internal.ProjectInfo, additional bool) (string, error) {
+	var options []wizardOption
+	group := ""
+	for _, project := range projects {
+		if project.Group != group || len(options) == 0 {
+			group = project.Group
+			options = append(options, wizardOption{label: fmt.Sprintf("─── %s ───", group)})
+		}
+		options = append(options, wizardOption{label: project.Name, key: project.URL})
+	}
+	return checkboxSelect("📦 Select projects (ENTER toggles)", "project", options, w.projSel, additional)
+}
+
+// unselectedProjects returns the projects not covered by the selected groups, for the mixed selection
+func (w *wizard) unselectedProjects() []internal.ProjectInfo {
+	var remaining []internal.ProjectInfo
+	for _, project := range w.projects {
+		if !w.groupSel[project.Group] {
+			remaining = append(remaining, project)
+		}
+	}
+	return remaining
+}
+
+// selectedURLs combines the selected groups and projects
+func (w *wizard) selectedURLs() map[string]bool {
+	selected := make(map[string]bool)
+	for _, project := range w.projects {
+		if w.groupSel[project.Group] || w.projSel[project.URL] {
+			selected[project.URL] = true
+		}
+	}
+	return selected
+}
+
+func (w *wizard) chooseProtocol() error {
+	cursor := 0
+	if w.protocol == "http" {
+		cursor = 1
+	}
+	choice, err := wizardChoiceAt("🔐 Choose Git protocol (SSH recommended for authenticated access)", []string{
+		"🔐 SSH - Secure, key-based authentication (Recommended)",
+		"🌐 HTTPS - Username/password or token authentication",
+	}, cursor)
+	if err != nil {
+		return err
+	}
+	w.protocol = []string{"ssh", "http"}[choice]
+	return nil
+}
+
+func (w *wizard) chooseParallel() error {
+	levels := []int{1, 3, 5, 10}
+	cursor := len(levels) - 1
+	for i, level := range levels {
+		if level == w.parallel {
+			cursor = i
+		}
+	}
+	choice, err := wizardChoiceAt("⚡ Choose parallel processing level", []string{
+		"🐌 Sequential (1) - One at a time, safest",
+		"🚶 Moderate (3) - Good balance of speed and safety",
+		"🏃 Fast (5) - Faster processing, more resource usage",
+		"🚀 Maximum (10) - Fastest, highest resource usage",
+	}, cursor)
+	if err != nil {
+		return err
+	}
+	w.parallel = levels[choice]
+	return nil
+}
+
+// chooseAdvanced asks for the output directory, dry run and verbosity
+func (w *wizard) chooseAdvanced() error {
+	prompt := promptui.Prompt{
+		Label:     "📂 Output directory",
+		Default:   w.directory,
+		AllowEdit: true,
+		Validate: func(input string) error {
+			if strings.TrimSpace(input) == "" {
+				return errors.New("the output directory cannot be empty")
+			}
+			return nil
+		},
+	}
+	dir, err := prompt.Run()
+	if err != nil {
+		return err
+	}
+	w.directory = strings.TrimSpace(dir)
+
+	cursor := 0
+	if w.dryRun {
+		cursor = 1
+	}
+	choice, err := wizardChoiceAt("🎬 Execution mode", []string{
+		"🚀 Execute - Run the git operations",
+		"🔍 Dry Run - Only show what would be done",
+	}, cursor)
+	if err != nil {
+		return err
+	}
+	w.dryRun = choice == 1
+
+	cursor = 0
+	if w.verbose {
+		cursor = 1
+	}
+	choice, err = wizardChoiceAt("📣 Output detail", []string{
+		"📝 Normal - Progress and summary",
+		"🔎 Verbose - Also every repository and path",
+	}, cursor)
+	if err != nil {
+		return err
+	}
+	w.verbose = choice == 1
+	return nil
+}
+
+// confirm shows what will run and asks to proceed; declining returns errWizardCancelled
+func (w *wizard) confirm(title, decline string) error {
+	selected := w.selectedURLs()
+	if len(selected) == 0 {
+		color.New(color.FgYellow).Println("⚠️  No projects selected")
+		return errWizardCancelled
+	}
+
+	fmt.Println()
+	color.New(color.FgCyan, color.Bold).Println(title)
+	color.New(color.FgCyan).Println(strings.Repeat("━", 36))
+	fmt.Printf("Operation: %s\n", w.operation)
+	fmt.Printf("Projects: %d selected\n", len(selected))
+	byGroup := make(map[string][]string)
+	for _, project := range w.projects {
+		if selected[project.URL] {
+			byGroup[project.Group] = append(byGroup[project.Group], project.Name)
+		}
+	}
+	for _, group := range w.groups {
+		if names := byGroup[group]; len(names) > 0 {
+			color.New(color.FgWhite).Printf("   📁 %s: %s\n", group, strings.Join(names, ", "))
+		}
+	}
+	fmt.Printf("Protocol: %s\n", w.protocol)
+	fmt.Printf("Parallel: %d concurrent operations\n", w.parallel)
+	if w.mode == "advanced" {
+		fmt.Printf("Directory: %s\n", w.directory)
+		fmt.Printf("Dry Run: %t\n", w.dryRun)
+		fmt.Printf("Verbose: %t\n", w.verbose)
+	}
+	fmt.Println()
+
+	label := "Continue?"
+	if w.mode == "advanced" {
+		label = "Execute with these advanced settings?"
+	}
+	choice, err := wizardChoice(label, []string{"✅ Yes - Proceed with operation", decline})
+	if err != nil {
+		return err
+	}
+	if choice != 0 {
+		return errWizardCancelled
+	}
+	return nil
+}
+
+// checkboxSelect shows a list where ENTER toggles items and runs actions; it returns the chosen action
+// Continue needs at least one ticked item unless allowEmpty is set
+func checkboxSelect(title, noun string, options []wizardOption, selected map[string]bool, allowEmpty bool) (string, error) {
+	cursor := 0
+	for {
+		count := 0
+		for _, option := range options {
+			if option.key != "" && selected[option.key] {
+				count++
+			}
+		}
+
+		items := make([]wizardOption, 0, len(options)+5)
+		items = append(items, options...)
+		items = append(items,
+			wizardOption{label: fmt.Sprintf("→ Continue with %d selected %s(s)", count, noun), action: "continue"},
+			wizardOption{label: "🧹 Clear all selections", action: "clear"},
+			wizardOption{label: fmt.Sprintf("🌟 Select all %ss", noun), action: "all"},
+			wizardOption{label: "◀️  Back to previous step", action: "back"},
+			wizardOption{label: "❌ Cancel wizard", action: "cancel"},
+		)
+
+		labels := make([]string, len(items))
+		for i, item := range items {
+			switch {
+			case item.key != "" && selected[item.key]:
+				labels[i] = "[✓] " + item.label
+			case item.key != "":
+				labels[i] = "[ ] " + item.label
+			default:
+				labels[i] = item.label
+			}
+		}
+
+		size := min(len(labels), 15)
+		prompt := promptui.Select{
+			Label:        fmt.Sprintf("%s · %d selected", title, count),
+			Items:        labels,
+			Size:         size,
+			HideSelected: true,
+			Templates:    checkboxTemplates,
+		}
+		index, _, err := prompt.RunCursorAt(cursor, clamp(cursor-size/2, 0, len(labels)-size))
+		if err != nil {
+			return "", err
+		}
+		cursor = index
+
+		item := items[index]
+		switch {
+		case item.key != "":
+			if selected[item.key] {
+				delete(selected, item.key)
+			} else {
+				selected[item.key] = true
+			}
+		case item.action == "clear":
+			for _, option := range options {
+				delete(selected, option.key)
+			}
+		case item.action == "all":
+			for _, option := range options {
+				if option.key != "" {
+					selected[option.key] = true
+				}
+			}
+		case item.action == "continue" && count == 0 && !allowEmpty:
+			color.New(color.FgYellow).Printf("⚠️  Select at least one %s, or go back\n", noun)
+		case item.action == "cancel":
+			sure, err := wizardChoice("Cancel the wizard? Nothing will be run", []string{"◀️  No - Keep selecting", "❌ Yes - Cancel"})
+			if err != nil {
+				return "", err
+			}
+			if sure == 1 {
+				return "cancel", nil
+			}
+		case item.action != "":
+			return item.action, nil
+		}
+	}
+}
+
+// wizardChoice asks for one of items and returns its index
+func wizardChoice(label string, items []string) (int, error) {
+	return wizardChoiceAt(label, items, 0)
+}
+
+func wizardChoiceAt(label string, items []string, cursor int) (int, error) {
+	prompt := promptui.Select{
+		Label:     label,
+		Items:     items,
+		Size:      len(items),
+		CursorPos: cursor,
+		Templates: wizardTemplates,
+	}
+	index, _, err := prompt.Run()
+	return index, err
+}
+
+// applyWizardSelection keeps the projects picked in the wizard
+func applyWizardSelection(projects []internal.ProjectInfo) []internal.ProjectInfo {
+	var selected []internal.ProjectInfo
+	for _, project := range projects {
+		if wizardSelection[project.URL] {
+			selected = append(selected, project)
+		}
+	}
+	return selected
+}
@@ -0,0 +1,153 @@
+package internal
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ChangelogCommit is one commit that arrived with a pull
+type ChangelogCommit struct {
+	Hash    string `json:"hash"`
+	Author  string `json:"author"`
+	Date    string `json:"date"`
+	Subject string `json:"subject"`
+}
+
+// RepositoryChangelog lists the commits a repository received between two HEADs
+type RepositoryChangelog struct {
+	ID      string            `json:"id"`
+	Project string            `json:"project"`
+	Group   string            `json:"group"`
+	Path    string            `json:"path"`
+	From    string            `json:"from"`
+	To      string            `json:"to"`
+	Commits []ChangelogCommit `json:"commits"`
+	Error   string            `json:"error,omitempty"`
+}
+
+// ChangelogFilter narrows the listed commits; empty fields match everything
+type ChangelogFilter struct {
+	Author string   // Matched against the author name and email, as git log --author does
+	Paths  []string // Only commits touching these paths
+}
+
+// IncomingCommits lists the commits in from..to of a repository, newest first
+func IncomingCommits(localPath, from, to string, filter ChangelogFilter) ([]ChangelogCommit, error) {
+	args := []string{"-C", localPath, "log", "--format=%H%x1f%an%x1f%aI%x1f%s", from + ".." + to}
+	if filter.Author != "" {
+		args = append(args, "--author="+filter.Author)
+	}
+	if len(filter.Paths) > 0 {
+		args = append(args, "--")
+		args = append(args, filter.Paths...)
+	}
+
+	output, err := RunGitCommand(args...)
+	if err != nil {
+		return nil, fmt.Errorf("git log failed%s", gitErrorDetail(output))
+	}
+
+	commits := []ChangelogCommit{}
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		fields := strings.SplitN(line, "\x1f", 4)
+		if len(fields) != 4 {
+			continue
+		}
+		commits = append(commits, ChangelogCommit{Hash: fields[0], Author: fields[1], Date: fields[2], Subject: fields[3]})
+	}
+	return commits, nil
+}
+
+// ChangelogFromSummary lists the incoming commits of every repository a run updated
+func ChangelogFromSummary(summary Summary, filter ChangelogFilter) []RepositoryChangelog {
+	var changelogs []RepositoryChangelog
+	for _, result := range summary.Results {
+		if HistoryOutcome(result) != HistoryUpdated || result.BeforeCommit == "" || result.AfterCommit == "" {
+			continue
+		}
+		changelogs = append(changelogs, newRepositoryChangelog(ProjectIdentity(result.Project), result.Project.Name,
+			result.Project.Group, result.Project.LocalPath, result.BeforeCommit, result.AfterCommit, filter))
+	}
+	return changelogs
+}
+
+// ChangelogFromHistory lists the incoming commits of every repository updated at or after since.
+// Several updates of one repository are merged into a single range, from the HEAD before the
+// first update to the HEAD after the last one.
+func ChangelogFromHistory(history *History, since time.Time, filter ChangelogFilter) []RepositoryChangelog {
+	var order []string
+	ranges := make(map[string]*HistoryEntry)
+	for i := range history.Entries {
+		entry := history.Entries[i]
+		if entry.Outcome != HistoryUpdated || entry.Before == "" || entry.After == "" || HistoryTime(entry.Time).Before(since) {
+			continue
+		}
+		if existing, ok := ranges[entry.ID]; ok {
+			existing.After = entry.After
+			existing.Path = entry.Path
+			existing.Project = entry.Project
+			existing.Group = entry.Group
+			continue
+		}
+		ranges[entry.ID] = &entry
+		order = append(order, entry.ID)
+	}
+
+	var changelogs []RepositoryChangelog
+	for _, id := range order {
+		entry := ranges[id]
+		changelogs = append(changelogs, newRepositoryChangelog(id, entry.Project, entry.Group, entry.Path, entry.Before, entry.After, filter))
+	}
+	return changelogs
+}
+
+// newRepositoryChangelog reads the commits of one range; a range that can no longer be read,
+// e.g. after a force push or a deleted clone, is reported with its error
+func newRepositoryChangelog(id, project, group, path, from, to string, filter ChangelogFilter) RepositoryChangelog {
+	changelog := RepositoryChangelog{ID: id, Project: project, Group: group, Path: path, From: from, To: to, Commits: []ChangelogCommit{}}
+	commits, err := IncomingCommits(path, from, to, filter)
+	if err != nil {
+		changelog.Error = err.Error()
+		return changelog
+	}
+	changelog.Commits = commits
+	return changelog
+}
+
+// RenderChangelogMarkdown renders the changelogs as a Markdown document, one section per
+// repository with commits
+func RenderChangelogMarkdown(title string, changelogs []RepositoryChangelog) []byte {
+	var b bytes.Buffer
+
+	fmt.Fprintf(&b, "# %s\n", title)
+	written := 0
+	for _, changelog := range changelogs {
+		if len(changelog.Commits) == 0 && changelog.Error == "" {
+			continue
+		}
+		written++
+
+		fmt.Fprintf(&b, "\n## %s (%s)\n\n", changelog.Project, changelog.Group)
+		if changelog.Error != "" {
+			fmt.Fprintf(&b, "_Could not read %s..%s: %s_\n", shortCommit(changelog.From), shortCommit(changelog.To), markdownCell(changelog.Error))
+			continue
+		}
+		for _, commit := range changelog.Commits {
+			fmt.Fprintf(&b, "- `%s` %s (%s)\n", shortCommit(commit.Hash), markdownCell(commit.Subject), markdownCell(commit.Author))
+		}
+	}
+	if written == 0 {
+		b.WriteString("\nNo incoming commits.\n")
+	}
+	return b.Bytes()
+}
+
+// shortCommit abbreviates a commit hash to the eight characters syncx shows everywhere
+func shortCommit(hash string) string {
+	if len(hash) > 8 {
+		return hash[:8]
+	}
+	return hash
+}
@@ -0,0 +1,101 @@
+package internal
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+const DefaultConfigFileName = ".olive-clone.yaml"
+
+// Config represents the optional user configuration file
+type Config struct {
+	// Maximum concurrent git network commands per remote host, e.g. {"gitlab.internal": 4, "github.com": 8}
+	HostLimits map[string]int `yaml:"host_limits"`
+	// Maximum git network commands started per minute and host (0 = unlimited)
+	RequestsPerMinute int `yaml:"requests_per_minute"`
+
+	// Structured log file (defaults to .olive-clone.log in the output directory)
+	LogFile       string `yaml:"log_file"`
+	LogLevel      string `yaml:"log_level"`
+	LogFormat     string `yaml:"log_format"`
+	LogMaxSizeMB  int    `yaml:"log_max_size_mb"`
+	LogMaxBackups int    `yaml:"log_max_backups"`
+
+	// How dirty and behind repositories appear in --junit reports
+	JUnit JUnitConfig `yaml:"junit"`
+
+	// Messages sent after clone and pull runs
+	Notifications []NotificationConfig `yaml:"notifications"`
+}
+
+// JUnitConfig sets the JUnit outcome of repositories that need attention but did not fail
+type JUnitConfig struct {
+	Dirty  string `yaml:"dirty"`  // failure (default) or skipped
+	Behind string `yaml:"behind"` // failure (default) or skipped
+}
+
+// DirtyOutcome returns the JUnit outcome for repositories with uncommitted changes
+func (c JUnitConfig) DirtyOutcome() string {
+	if c.Dirty == "" {
+		return JUnitFailure
+	}
+	return c.Dirty
+}
+
+// BehindOutcome returns the JUnit outcome for repositories behind their remote
+func (c JUnitConfig) BehindOutcome() string {
+	if c.Behind == "" {
+		return JUnitFailure
+	}
+	return c.Behind
+}
+
+// DefaultConfigPath returns $HOME/.olive-clone.yaml
+func DefaultConfigPath() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(homeDir, DefaultConfigFileName)
+}
+
+// LoadConfig loads the configuration file
+// A missing file is only an error when it was requested explicitly
+func LoadConfig(path string, explicit bool) (*Config, error) {
+	config := &Config{}
+	if path == "" {
+		return config, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) && !explicit {
+		return config, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file %s: %w", path, err)
+	}
+
+	if err := yaml.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+
+	for _, outcome := range []string{config.JUnit.Dirty, config.JUnit.Behind} {
+		if outcome == "" {
+			continue
+		}
+		if err := ValidateJUnitOutcome(outcome); err != nil {
+			return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+		}
+	}
+
+	for _, notification := range config.Notifications {
+		if err := ValidateNotification(notification); err != nil {
+			return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+		}
+	}
+
+	return config, nil
+}
@@ -0,0 +1,230 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultLogFileName is the log file written to the output directory when --log-file is not set
+const DefaultLogFileName = ".olive-clone.log"
+
+// Log file formats
+const (
+	LogFormatJSON   = "json"
+	LogFormatLogfmt = "logfmt"
+)
+
+// LogFileOptions configures the structured log file
+type LogFileOptions struct {
+	Path       string
+	Level      slog.Level
+	Format     string // json or logfmt
+	MaxSize    int64  // Rotate once the file grows beyond this many bytes
+	MaxBackups int    // Rotated files to keep (.1 is the newest)
+	CreateDir  bool   // Create the parent directory; otherwise nothing is written until it exists
+}
+
+// fileLog receives structured records independent of the console verbosity
+var fileLog = slog.New(slog.NewTextHandler(io.Discard, nil))
+var logWriter *rotatingWriter
+
+// ParseLogLevel parses debug, info, warn or error
+func ParseLogLevel(level string) (slog.Level, error) {
+	var parsed slog.Level
+	if err := parsed.UnmarshalText([]byte(level)); err != nil {
+		return 0, fmt.Errorf("invalid log level: %s. Must be 'debug', 'info', 'warn' or 'error'", level)
+	}
+	return parsed, nil
+}
+
+// OpenLogFile starts writing structured records to a rotating log file
+func OpenLogFile(options LogFileOptions) error {
+	if options.Format != LogFormatJSON && options.Format != LogFormatLogfmt {
+		return fmt.Errorf("invalid log format: %s. Must be 'json' or 'logfmt'", options.Format)
+	}
+
+	logWriter = &rotatingWriter{
+		path:       options.Path,
+		maxSize:    options.MaxSize,
+		maxBackups: options.MaxBackups,
+		createDir:  options.CreateDir,
+	}
+
+	handlerOptions := &slog.HandlerOptions{Level: options.Level}
+	if options.Format == LogFormatJSON {
+		fileLog = slog.New(slog.NewJSONHandler(logWriter, handlerOptions))
+	} else {
+		fileLog = slog.New(slog.NewTextHandler(logWriter, handlerOptions))
+	}
+	return nil
+}
+
+// CloseLogFile flushes and closes the log file
+func CloseLogFile() {
+	if logWriter != nil {
+		logWriter.Close()
+	}
+}
+
+// FileLog returns the structured file logger
+func FileLog() *slog.Logger {
+	return fileLog
+}
+
+// logGitCommand records one git invocation with its outcome
+func logGitCommand(args []string, duration time.Duration, err error, stderr string) {
+	attrs := []any{
+		"args", strings.Join(args, " "),
+		"duration", duration.String(),
+		"exit_code", gitExitCode(err),
+	}
+	if stderr = strings.TrimSpace(stderr); stderr != "" {
+		attrs = append(attrs, "stderr", stderr)
+	}
+
+	// Failures are warnings here; the clone or pull they belong to is logged as an error
+	if err != nil {
+		fileLog.Warn("git command failed", append(attrs, "error", err.Error())...)
+		return
+	}
+	fileLog.Info("git command", attrs...)
+}
+
+// gitExitCode returns the process exit code, or -1 if git did not exit on its own (timeout, not found)
+func gitExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return -1
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode()
+	}
+	return -1
+}
+
+// LogOperationResult records the outcome of a clone or pull
+func LogOperationResult(result OperationResult) {
+	operation := "pull"
+	if result.IsClone {
+		operation = "clone"
+	}
+	attrs := []any{
+		"operation", operation,
+		"project", result.Project.Name,
+		"url", result.Project.URL,
+		"path", result.Project.LocalPath,
+		"attempts", result.Attempts,
+		"duration", result.Duration,
+	}
+
+	switch {
+	case result.Success:
+		fileLog.Info("operation succeeded", attrs...)
+	case result.IsEmpty:
+		fileLog.Warn("empty repository", attrs...)
+	default:
+		fileLog.Error("operation failed", append(attrs, "message", result.Message)...)
+	}
+}
+
+// rotatingWriter is a size-based rotating log file, opened on first write
+type rotatingWriter struct {
+	path       string
+	maxSize    int64
+	maxBackups int
+	createDir  bool
+
+	mu   sync.Mutex
+	file *os.File
+	size int64
+}
+
+func (w *rotatingWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.file == nil {
+		if err := w.open(); err != nil {
+			// The log file must never break a run; drop the record
+			return len(p), nil
+		}
+	}
+
+	if w.maxSize > 0 && w.size > 0 && w.size+int64(len(p)) > w.maxSize {
+		if err := w.rotate(); err != nil {
+			return len(p), nil
+		}
+	}
+
+	n, err := w.file.Write(p)
+	w.size += int64(n)
+	return n, err
+}
+
+// Close closes the current file; a later write reopens it
+func (w *rotatingWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.file == nil {
+		return nil
+	}
+	err := w.file.Close()
+	w.file = nil
+	return err
+}
+
+func (w *rotatingWriter) open() error {
+	dir := filepath.Dir(w.path)
+	if w.createDir {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+	} else if _, err := os.Stat(dir); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(w.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	w.file = file
+	w.size = info.Size()
+	return nil
+}
+
+// rotate shifts log → log.1 → log.2 … and drops the oldest backup
+func (w *rotatingWriter) rotate() error {
+	w.file.Close()
+	w.file = nil
+
+	if w.maxBackups > 0 {
+		os.Remove(fmt.Sprintf("%s.%d", w.path, w.maxBackups))
+		for i := w.maxBackups - 1; i >= 1; i-- {
+			os.Rename(fmt.Sprintf("%s.%d", w.path, i), fmt.Sprintf("%s.%d", w.path, i+1))
+		}
+		os.Rename(w.path, w.path+".1")
+	} else {
+		os.Remove(w.path)
+	}
+
+	return w.open()
+}
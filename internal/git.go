@@ -1,8 +1,11 @@
 package internal
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"net/url"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -12,20 +15,101 @@ import (
 
 // runGitCommandWithTimeout runs a git command with a timeout
 func runGitCommandWithTimeout(timeout time.Duration, args ...string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
-	defer cancel()
-
-	cmd := exec.CommandContext(ctx, "git", args...)
-	return cmd.Run()
+	_, _, err := runGitCommandCapture(timeout, args...)
+	return err
 }
 
 // runGitCommandWithOutputAndTimeout runs a git command with timeout and returns output
 func runGitCommandWithOutputAndTimeout(timeout time.Duration, args ...string) ([]byte, error) {
+	stdout, stderr, err := runGitCommandCapture(timeout, args...)
+	return append(stdout, []byte(stderr)...), err
+}
+
+// runGitCommandCapture runs a git command with timeout and returns stdout and stderr separately
+// If the timeout expires, the returned error wraps context.DeadlineExceeded
+func runGitCommandCapture(timeout time.Duration, args ...string) ([]byte, string, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
+	var stdout, stderr bytes.Buffer
 	cmd := exec.CommandContext(ctx, "git", args...)
-	return cmd.CombinedOutput()
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	observeGitCommand(GitCommandEvent{Args: args})
+	start := time.Now()
+	err := cmd.Run()
+	if err != nil && ctx.Err() != nil {
+		err = fmt.Errorf("timed out after %s: %w", timeout, ctx.Err())
+	}
+	logGitCommand(args, time.Since(start), err, stderr.String())
+	observeGitCommand(GitCommandEvent{Args: args, Done: true, Duration: time.Since(start), Stdout: stdout.String(), Stderr: stderr.String(), Err: err})
+	return stdout.Bytes(), stderr.String(), err
+}
+
+// RunGitCommand runs a local git command (no network, no timeout) and returns its stdout
+// The command and its stderr are recorded in the log file
+func RunGitCommand(args ...string) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("git", args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	observeGitCommand(GitCommandEvent{Args: args})
+	start := time.Now()
+	err := cmd.Run()
+	logGitCommand(args, time.Since(start), err, stderr.String())
+	observeGitCommand(GitCommandEvent{Args: args, Done: true, Duration: time.Since(start), Stdout: stdout.String(), Stderr: stderr.String(), Err: err})
+	return stdout.Bytes(), err
+}
+
+// GitCommandEvent describes a git command that started or finished, for live views like the terminal UI
+type GitCommandEvent struct {
+	Args     []string
+	Done     bool
+	Duration time.Duration
+	Stdout   string
+	Stderr   string
+	Err      error
+}
+
+var gitObserver func(GitCommandEvent)
+
+// SetGitCommandObserver registers a function called when each git command starts and finishes
+// It must be set before operations start and cleared (nil) after they finish
+func SetGitCommandObserver(observer func(GitCommandEvent)) {
+	gitObserver = observer
+}
+
+func observeGitCommand(event GitCommandEvent) {
+	if gitObserver != nil {
+		gitObserver(event)
+	}
+}
+
+// GitCommandPath returns the repository a git command works on: the -C directory or the clone target
+func GitCommandPath(args []string) string {
+	if len(args) >= 2 && args[0] == "-C" {
+		return args[1]
+	}
+	for _, arg := range args {
+		if arg == "clone" {
+			return args[len(args)-1]
+		}
+	}
+	return ""
+}
+
+// GitSubcommand returns the git subcommand, e.g. "fetch" for "git -C path fetch --quiet"
+func GitSubcommand(args []string) string {
+	for i := 0; i < len(args); i++ {
+		if args[i] == "-C" || args[i] == "-c" {
+			i++
+			continue
+		}
+		if !strings.HasPrefix(args[i], "-") {
+			return args[i]
+		}
+	}
+	return ""
 }
 
 // formatGitURL converts base URL to proper git clone URL based on protocol
@@ -55,6 +139,44 @@ func FormatGitURL(baseURL, protocol string) string {
 	}
 }
 
+// NormalizeRemoteURL reduces a git remote to "host/path" so the SSH, HTTPS and inventory
+// spellings of the same repository compare equal, e.g. "git@gitlab.com:team/app.git",
+// "https://gitlab.com/team/app" and "gitlab.com:team/app.git" all become "gitlab.com/team/app"
+func NormalizeRemoteURL(remoteURL string) string {
+	remote := strings.TrimSpace(remoteURL)
+	var host, path string
+	if strings.Contains(remote, "://") {
+		parsed, err := url.Parse(remote)
+		if err != nil {
+			return strings.ToLower(remote)
+		}
+		host, path = parsed.Hostname(), parsed.Path
+	} else {
+		if at := strings.Index(remote, "@"); at >= 0 {
+			remote = remote[at+1:]
+		}
+		if colon := strings.Index(remote, ":"); colon >= 0 {
+			host, path = remote[:colon], remote[colon+1:]
+		} else if slash := strings.Index(remote, "/"); slash >= 0 {
+			host, path = remote[:slash], remote[slash+1:]
+		} else {
+			host = remote
+		}
+	}
+
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+	return strings.ToLower(host + "/" + path)
+}
+
+// GetRemoteURL returns the origin URL of a local repository
+func GetRemoteURL(localPath string) (string, error) {
+	output, err := RunGitCommand("-C", localPath, "config", "--get", "remote.origin.url")
+	if err != nil {
+		return "", fmt.Errorf("no origin remote: %w", err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
 // extractDirectoryPath extracts directory path from git URL
 func ExtractDirectoryPath(gitURL string) string {
 	if strings.Contains(gitURL, "gitlab.com") {
@@ -169,15 +291,14 @@ func IsEmptyRepository(path string) bool {
 
 	// Try to get the current commit hash
 	// If this fails with exit code 128, the repository is empty (no commits)
-	cmd := exec.Command("git", "-C", path, "rev-parse", "HEAD")
-	err := cmd.Run()
+	_, err := RunGitCommand("-C", path, "rev-parse", "HEAD")
 
 	// If command fails, repository is empty (no commits yet)
 	return err != nil
 }
 
 // CloneRepository clones a repository to the specified local path
-func CloneRepository(repoURL, localPath string, logger *Logger) OperationResult {
+func CloneRepository(repoURL, localPath string, timeout time.Duration, logger *Logger) OperationResult {
 	start := time.Now()
 
 	// Create parent directory if it doesn't exist
@@ -193,23 +314,31 @@ func CloneRepository(repoURL, localPath string, logger *Logger) OperationResult
 
 	logger.Cloning("%s -> %s", repoURL, localPath)
 
-	// Fast clone with timeout (60 seconds) and shallow depth for speed
-	output, err := runGitCommandWithOutputAndTimeout(60*time.Second, "clone", "--depth=1", "--single-branch", "--quiet", repoURL, localPath)
+	// Fast clone with timeout and shallow depth for speed
+	output, attempts, err := runGitNetworkCommand(cloneTimeout(timeout), removePartialClone(localPath), "clone", "--depth=1", "--single-branch", "--quiet", repoURL, localPath)
 	if err != nil {
+		timedOut := errors.Is(err, context.DeadlineExceeded)
+		if timedOut {
+			// git was killed before it could remove its half-written clone
+			removePartialClone(localPath)()
+		}
 		return OperationResult{
-			Success: false,
-			Message: fmt.Sprintf("Failed to clone %s: %v - Output: %s", repoURL, err, string(output)),
-			IsClone: true,
+			Success:  false,
+			Message:  fmt.Sprintf("Failed to clone %s: %v - Output: %s", repoURL, err, string(output)),
+			IsClone:  true,
+			Attempts: attempts,
 			Duration: time.Since(start).String(),
+			TimedOut: timedOut,
 		}
 	}
 
 	// Verify the clone was successful by checking if .git directory exists
 	if !IsGitRepository(localPath) {
 		return OperationResult{
-			Success: false,
-			Message: fmt.Sprintf("Clone completed but no git repository found at %s", localPath),
-			IsClone: true,
+			Success:  false,
+			Message:  fmt.Sprintf("Clone completed but no git repository found at %s", localPath),
+			IsClone:  true,
+			Attempts: attempts,
 			Duration: time.Since(start).String(),
 		}
 	}
@@ -221,22 +350,23 @@ func CloneRepository(repoURL, localPath string, logger *Logger) OperationResult
 	}
 
 	// Fetch all branches from remote
-	if err := fetchAllBranches(localPath); err != nil {
+	if err := fetchAllBranches(localPath, cloneTimeout(timeout)); err != nil {
 		logger.Warning("Could not fetch all branches for %s: %v", localPath, err)
 		// Don't fail the clone operation for this
 	}
 
 	logger.Success("Cloned: %s", filepath.Base(localPath))
 	return OperationResult{
-		Success: true,
-		Message: fmt.Sprintf("Successfully cloned %s", repoURL),
-		IsClone: true,
+		Success:  true,
+		Message:  fmt.Sprintf("Successfully cloned %s", repoURL),
+		IsClone:  true,
+		Attempts: attempts,
 		Duration: time.Since(start).String(),
 	}
 }
 
 // PullRepository pulls latest changes from a git repository
-func PullRepository(localPath string, logger *Logger) OperationResult {
+func PullRepository(localPath string, timeout time.Duration, logger *Logger) OperationResult {
 	start := time.Now()
 
 	// Check if repository is empty (no commits)
@@ -253,24 +383,30 @@ func PullRepository(localPath string, logger *Logger) OperationResult {
 
 	logger.Pulling("Getting latest changes: %s", localPath)
 
-	// Fast fetch with timeout (30 seconds) - only fetch current branch
-	if err := runGitCommandWithTimeout(30*time.Second, "-C", localPath, "fetch", "--quiet"); err != nil {
+	// Fast fetch with timeout - only fetch current branch
+	_, fetchAttempts, err := runGitNetworkCommand(fetchTimeout(timeout), nil, "-C", localPath, "fetch", "--quiet")
+	if err != nil {
 		logger.Warning("Fetch failed for %s: %v", localPath, err)
 	}
 
-	// Fast pull with timeout (30 seconds) - no-stat for speed
-	output, err := runGitCommandWithOutputAndTimeout(30*time.Second, "-C", localPath, "pull", "--ff-only", "--no-stat", "--quiet")
+	// Fast pull with timeout - no-stat for speed
+	output, pullAttempts, err := runGitNetworkCommand(fetchTimeout(timeout), nil, "-C", localPath, "pull", "--ff-only", "--no-stat", "--quiet")
+	attempts := countAttempts(fetchAttempts, pullAttempts)
 	if err != nil {
 		// Try again without --ff-only in case there are conflicts
 		logger.Warning("Fast-forward pull failed, trying regular pull...")
-		output, err = runGitCommandWithOutputAndTimeout(30*time.Second, "-C", localPath, "pull", "--no-stat", "--quiet")
+		var fallbackAttempts int
+		output, fallbackAttempts, err = runGitNetworkCommand(fetchTimeout(timeout), nil, "-C", localPath, "pull", "--no-stat", "--quiet")
+		attempts = countAttempts(fetchAttempts, pullAttempts, fallbackAttempts)
 		if err != nil {
 			return OperationResult{
-				Success: false,
-				Message: fmt.Sprintf("Failed to pull %s: %v - Output: %s", localPath, err, string(output)),
-				IsClone: false,
-				IsEmpty: false,
+				Success:  false,
+				Message:  fmt.Sprintf("Failed to pull %s: %v - Output: %s", localPath, err, string(output)),
+				IsClone:  false,
+				IsEmpty:  false,
+				Attempts: attempts,
 				Duration: time.Since(start).String(),
+				TimedOut: errors.Is(err, context.DeadlineExceeded),
 			}
 		}
 	}
@@ -279,19 +415,21 @@ func PullRepository(localPath string, logger *Logger) OperationResult {
 	if strings.Contains(outputStr, "Already up to date") || strings.Contains(outputStr, "Already up-to-date") {
 		logger.Info("Already up to date: %s", filepath.Base(localPath))
 		return OperationResult{
-			Success: true,
-			Message: "Already up to date",
-			IsClone: false,
-			IsEmpty: false,
+			Success:  true,
+			Message:  "Already up to date",
+			IsClone:  false,
+			IsEmpty:  false,
+			Attempts: attempts,
 			Duration: time.Since(start).String(),
 		}
 	} else {
 		logger.Updated("Updated: %s", filepath.Base(localPath))
 		return OperationResult{
-			Success: true,
-			Message: fmt.Sprintf("Successfully updated - %s", strings.TrimSpace(outputStr)),
-			IsClone: false,
-			IsEmpty: false,
+			Success:  true,
+			Message:  fmt.Sprintf("Successfully updated - %s", strings.TrimSpace(outputStr)),
+			IsClone:  false,
+			IsEmpty:  false,
+			Attempts: attempts,
 			Duration: time.Since(start).String(),
 		}
 	}
@@ -313,7 +451,7 @@ func CloneOrUpdateRepository(project ProjectInfo, dryRun bool, logger *Logger) O
 	if _, err := os.Stat(project.LocalPath); err == nil {
 		if IsGitRepository(project.LocalPath) {
 			// It's a git repository, pull latest changes
-			result := PullRepository(project.LocalPath, logger)
+			result := PullRepository(project.LocalPath, project.FetchTimeout, logger)
 			result.Project = project
 			
 			// Update tracker if operation was successful
@@ -342,7 +480,7 @@ func CloneOrUpdateRepository(project ProjectInfo, dryRun bool, logger *Logger) O
 	}
 
 	// Directory doesn't exist, clone the repository
-	result := CloneRepository(project.GitURL, project.LocalPath, logger)
+	result := CloneRepository(project.GitURL, project.LocalPath, project.CloneTimeout, logger)
 	result.Project = project
 	
 	// Update tracker if clone was successful
@@ -375,7 +513,7 @@ func CloneOrUpdateRepositorySilent(project ProjectInfo, dryRun bool, logger *Log
 	if _, err := os.Stat(project.LocalPath); err == nil {
 		if IsGitRepository(project.LocalPath) {
 			// It's a git repository, pull latest changes (silently)
-			result := PullRepositorySilent(project.LocalPath)
+			result := PullRepositorySilent(project.LocalPath, project.FetchTimeout)
 			result.Project = project
 			
 			// Update tracker if operation was successful
@@ -403,7 +541,7 @@ func CloneOrUpdateRepositorySilent(project ProjectInfo, dryRun bool, logger *Log
 	}
 
 	// Directory doesn't exist, clone the repository (silently)
-	result := CloneRepositorySilent(project.GitURL, project.LocalPath)
+	result := CloneRepositorySilent(project.GitURL, project.LocalPath, project.CloneTimeout)
 	result.Project = project
 	
 	// Update tracker if clone was successful
@@ -421,28 +559,57 @@ func CloneOrUpdateRepositorySilent(project ProjectInfo, dryRun bool, logger *Log
 	return result
 }
 
+// removePartialClone returns a cleanup hook that deletes a half-written clone before a retry
+func removePartialClone(localPath string) func() {
+	return func() {
+		os.RemoveAll(localPath)
+	}
+}
+
+// gitErrorDetail picks the most useful line of git's output for inclusion in an error message
+func gitErrorDetail(output []byte) string {
+	trimmed := strings.TrimSpace(string(output))
+	if trimmed == "" {
+		return ""
+	}
+	lines := strings.Split(trimmed, "\n")
+	for _, line := range lines {
+		if strings.HasPrefix(line, "fatal:") || strings.HasPrefix(line, "error:") {
+			return " - " + strings.TrimSpace(line)
+		}
+	}
+	return " - " + strings.TrimSpace(lines[len(lines)-1])
+}
+
 // fixRefspec fixes the git refspec after cloning with --single-branch
 // This allows fetching all branches later with git fetch
 func fixRefspec(localPath string) error {
 	// Set the correct refspec for fetching all branches
-	cmd := exec.Command("git", "-C", localPath, "config", "remote.origin.fetch", "+refs/heads/*:refs/remotes/origin/*")
-	if err := cmd.Run(); err != nil {
+	if _, err := RunGitCommand("-C", localPath, "config", "remote.origin.fetch", "+refs/heads/*:refs/remotes/origin/*"); err != nil {
 		return fmt.Errorf("failed to fix refspec: %w", err)
 	}
 	return nil
 }
 
 // fetchAllBranches fetches all branches from remote after fixing refspec
-func fetchAllBranches(localPath string) error {
-	// Fetch all branches with timeout (30 seconds)
-	if err := runGitCommandWithTimeout(30*time.Second, "-C", localPath, "fetch", "--all", "--quiet"); err != nil {
+func fetchAllBranches(localPath string, timeout time.Duration) error {
+	// Fetch all branches with timeout
+	if _, _, err := runGitNetworkCommand(fetchTimeout(timeout), nil, "-C", localPath, "fetch", "--all", "--quiet"); err != nil {
 		return fmt.Errorf("failed to fetch all branches: %w", err)
 	}
 	return nil
 }
 
+// FetchRepository updates the remote-tracking branches of a repository without touching the working tree
+func FetchRepository(localPath string, timeout time.Duration) error {
+	if output, _, err := runGitNetworkCommand(fetchTimeout(timeout), nil, "-C", localPath, "fetch", "--quiet"); err != nil {
+		return fmt.Errorf("%v%s", err, gitErrorDetail(output))
+	}
+	return nil
+}
+
 // CloneRepositorySilent clones a repository without logging output
-func CloneRepositorySilent(repoURL, localPath string) OperationResult {
+func CloneRepositorySilent(repoURL, localPath string, timeout time.Duration) OperationResult {
 	start := time.Now()
 
 	// Create parent directory if it doesn't exist
@@ -456,12 +623,20 @@ func CloneRepositorySilent(repoURL, localPath string) OperationResult {
 	}
 
 	// Fast clone with timeout and shallow depth
-	if err := runGitCommandWithTimeout(60*time.Second, "clone", "--depth=1", "--single-branch", "--quiet", repoURL, localPath); err != nil {
+	output, attempts, err := runGitNetworkCommand(cloneTimeout(timeout), removePartialClone(localPath), "clone", "--depth=1", "--single-branch", "--quiet", repoURL, localPath)
+	if err != nil {
+		timedOut := errors.Is(err, context.DeadlineExceeded)
+		if timedOut {
+			// git was killed before it could remove its half-written clone
+			removePartialClone(localPath)()
+		}
 		return OperationResult{
 			Success:  false,
-			Message:  fmt.Sprintf("Clone failed: %v", err),
+			Message:  fmt.Sprintf("Clone failed: %v%s", err, gitErrorDetail(output)),
 			IsClone:  true,
+			Attempts: attempts,
 			Duration: time.Since(start).String(),
+			TimedOut: timedOut,
 		}
 	}
 
@@ -472,7 +647,7 @@ func CloneRepositorySilent(repoURL, localPath string) OperationResult {
 	}
 
 	// Fetch all branches from remote
-	if err := fetchAllBranches(localPath); err != nil {
+	if err := fetchAllBranches(localPath, cloneTimeout(timeout)); err != nil {
 		// Log warning but don't fail the clone operation
 		// The repository is still usable, just with limited branch visibility
 	}
@@ -481,12 +656,13 @@ func CloneRepositorySilent(repoURL, localPath string) OperationResult {
 		Success:  true,
 		Message:  "Cloned successfully",
 		IsClone:  true,
+		Attempts: attempts,
 		Duration: time.Since(start).String(),
 	}
 }
 
 // PullRepositorySilent pulls latest changes without logging output
-func PullRepositorySilent(localPath string) OperationResult {
+func PullRepositorySilent(localPath string, timeout time.Duration) OperationResult {
 	start := time.Now()
 
 	// Check if repository is empty (no commits)
@@ -500,19 +676,21 @@ func PullRepositorySilent(localPath string) OperationResult {
 		}
 	}
 
-	// Fast fetch with timeout
-	if err := runGitCommandWithTimeout(30*time.Second, "-C", localPath, "fetch", "--quiet"); err != nil {
-		// Fetch failed, but continue with pull attempt
-	}
+	// Fast fetch with timeout (a failed fetch doesn't stop the pull attempt)
+	_, fetchAttempts, _ := runGitNetworkCommand(fetchTimeout(timeout), nil, "-C", localPath, "fetch", "--quiet")
 
 	// Execute git pull silently with timeout and optimizations
-	if err := runGitCommandWithTimeout(30*time.Second, "-C", localPath, "pull", "--ff-only", "--no-stat", "--quiet"); err != nil {
+	output, pullAttempts, err := runGitNetworkCommand(fetchTimeout(timeout), nil, "-C", localPath, "pull", "--ff-only", "--no-stat", "--quiet")
+	attempts := countAttempts(fetchAttempts, pullAttempts)
+	if err != nil {
 		return OperationResult{
 			Success:  false,
-			Message:  fmt.Sprintf("Pull failed: %v", err),
+			Message:  fmt.Sprintf("Pull failed: %v%s", err, gitErrorDetail(output)),
 			IsClone:  false,
 			IsEmpty:  false,
+			Attempts: attempts,
 			Duration: time.Since(start).String(),
+			TimedOut: errors.Is(err, context.DeadlineExceeded),
 		}
 	}
 
@@ -521,14 +699,14 @@ func PullRepositorySilent(localPath string) OperationResult {
 		Message:  "Updated successfully",
 		IsClone:  false,
 		IsEmpty:  false,
+		Attempts: attempts,
 		Duration: time.Since(start).String(),
 	}
 }
 
 // GetGitBranch returns the current branch name for a repository
 func GetGitBranch(path string) (string, error) {
-	cmd := exec.Command("git", "-C", path, "branch", "--show-current")
-	output, err := cmd.Output()
+	output, err := RunGitCommand("-C", path, "branch", "--show-current")
 	if err != nil {
 		return "", err
 	}
@@ -538,8 +716,7 @@ func GetGitBranch(path string) (string, error) {
 // CheckRepositoryChanges checks for uncommitted changes in a repository
 // Returns: (modified, staged, untracked, error)
 func CheckRepositoryChanges(path string) (int, int, int, error) {
-	cmd := exec.Command("git", "-C", path, "status", "--porcelain")
-	output, err := cmd.Output()
+	output, err := RunGitCommand("-C", path, "status", "--porcelain")
 	if err != nil {
 		return 0, 0, 0, err
 	}
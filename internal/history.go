@@ -0,0 +1,253 @@
+package internal
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HistoryFileName is the append-only log of every run and every project operation
+const HistoryFileName = ".olive-clone-history.jsonl"
+
+// Outcomes of a project operation in the history
+const (
+	HistoryCloned    = "cloned"
+	HistoryUpdated   = "updated"   // pulled and HEAD moved
+	HistoryUnchanged = "unchanged" // pulled, nothing new
+	HistoryEmpty     = "empty"
+	HistoryFailed    = "failed"
+)
+
+// HistoryRun is one run of a command; Type is "run"
+type HistoryRun struct {
+	Type       string  `json:"type"`
+	RunID      string  `json:"run_id"`
+	Command    string  `json:"command"`
+	StartedAt  string  `json:"started_at"`
+	FinishedAt string  `json:"finished_at"`
+	Seconds    float64 `json:"duration_seconds"`
+	Total      int     `json:"total"`
+	Cloned     int     `json:"cloned"`
+	Updated    int     `json:"updated"`
+	Unchanged  int     `json:"unchanged"`
+	Empty      int     `json:"empty"`
+	Failed     int     `json:"failed"`
+	Skipped    int     `json:"skipped"`
+}
+
+// HistoryEntry is the outcome of one project in a run; Type is "project"
+type HistoryEntry struct {
+	Type     string  `json:"type"`
+	RunID    string  `json:"run_id"`
+	Command  string  `json:"command"`
+	Time     string  `json:"time"`
+	ID       string  `json:"id"`
+	Project  string  `json:"project"`
+	Group    string  `json:"group"`
+	Path     string  `json:"path"`
+	Outcome  string  `json:"outcome"`
+	Before   string  `json:"before,omitempty"`
+	After    string  `json:"after,omitempty"`
+	Seconds  float64 `json:"duration_seconds"`
+	Attempts int     `json:"attempts,omitempty"`
+	Message  string  `json:"message,omitempty"`
+}
+
+// History is what was read back from the history file, oldest first
+type History struct {
+	Runs    []HistoryRun
+	Entries []HistoryEntry
+	Corrupt int // Lines that could not be parsed, e.g. from an interrupted write
+}
+
+// HistoryOutcome classifies an operation result for the history
+func HistoryOutcome(result OperationResult) string {
+	switch {
+	case result.IsEmpty:
+		return HistoryEmpty
+	case !result.Success:
+		return HistoryFailed
+	case result.IsClone:
+		return HistoryCloned
+	case result.BeforeCommit != "" && result.BeforeCommit == result.AfterCommit:
+		return HistoryUnchanged
+	default:
+		return HistoryUpdated
+	}
+}
+
+// AppendHistory adds a finished run and the outcome of each of its projects to the history
+// of the output directory. Records are only ever appended. It returns the id of the run.
+func AppendHistory(outputDir, command string, startedAt time.Time, summary Summary) (string, error) {
+	finishedAt := time.Now()
+	run := HistoryRun{
+		Type:       "run",
+		RunID:      startedAt.Format("20060102-150405.000"),
+		Command:    command,
+		StartedAt:  startedAt.Format(time.RFC3339),
+		FinishedAt: finishedAt.Format(time.RFC3339),
+		Seconds:    roundSeconds(finishedAt.Sub(startedAt)),
+		Total:      summary.TotalProjects,
+		Skipped:    summary.SkippedCount,
+	}
+
+	var lines []interface{}
+	for _, result := range summary.Results {
+		entry := HistoryEntry{
+			Type:     "project",
+			RunID:    run.RunID,
+			Command:  command,
+			Time:     run.FinishedAt,
+			ID:       ProjectIdentity(result.Project),
+			Project:  result.Project.Name,
+			Group:    result.Project.Group,
+			Path:     result.Project.LocalPath,
+			Outcome:  HistoryOutcome(result),
+			Before:   result.BeforeCommit,
+			After:    result.AfterCommit,
+			Attempts: result.Attempts,
+		}
+		if d, err := time.ParseDuration(result.Duration); err == nil {
+			entry.Seconds = roundSeconds(d)
+		}
+		if entry.Outcome == HistoryFailed {
+			entry.Message = result.Message
+		}
+
+		switch entry.Outcome {
+		case HistoryCloned:
+			run.Cloned++
+		case HistoryUpdated:
+			run.Updated++
+		case HistoryUnchanged:
+			run.Unchanged++
+		case HistoryEmpty:
+			run.Empty++
+		case HistoryFailed:
+			run.Failed++
+		}
+		lines = append(lines, entry)
+	}
+	lines = append([]interface{}{run}, lines...)
+
+	// One write per run keeps concurrent runs from interleaving their lines
+	var buffer strings.Builder
+	for _, line := range lines {
+		data, err := json.Marshal(line)
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal history: %w", err)
+		}
+		buffer.Write(data)
+		buffer.WriteByte('\n')
+	}
+
+	historyPath := filepath.Join(outputDir, HistoryFileName)
+	f, err := os.OpenFile(historyPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return "", fmt.Errorf("failed to open history file: %w", err)
+	}
+	defer f.Close()
+	if _, err := f.WriteString(buffer.String()); err != nil {
+		return "", fmt.Errorf("failed to write history file: %w", err)
+	}
+	return run.RunID, nil
+}
+
+// LoadHistory reads the history of the output directory; a missing file is an empty history
+func LoadHistory(outputDir string) (*History, error) {
+	history := &History{}
+
+	f, err := os.Open(filepath.Join(outputDir, HistoryFileName))
+	if os.IsNotExist(err) {
+		return history, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read history file: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(strings.TrimSpace(string(line))) == 0 {
+			continue
+		}
+
+		var record struct {
+			Type string `json:"type"`
+		}
+		if err := json.Unmarshal(line, &record); err != nil {
+			history.Corrupt++
+			continue
+		}
+		switch record.Type {
+		case "run":
+			var run HistoryRun
+			if err := json.Unmarshal(line, &run); err != nil {
+				history.Corrupt++
+				continue
+			}
+			history.Runs = append(history.Runs, run)
+		case "project":
+			var entry HistoryEntry
+			if err := json.Unmarshal(line, &entry); err != nil {
+				history.Corrupt++
+				continue
+			}
+			history.Entries = append(history.Entries, entry)
+		default:
+			history.Corrupt++
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read history file: %w", err)
+	}
+	return history, nil
+}
+
+// HistoryTime parses the time of a history record
+func HistoryTime(value string) time.Time {
+	t, _ := time.Parse(time.RFC3339, value)
+	return t
+}
+
+// ParseTimeBound reads a --since/--until value: a duration back from now ("36h", "7d",
+// "2w"), a date ("2024-05-01") or an RFC 3339 timestamp
+func ParseTimeBound(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	for _, unit := range []struct {
+		suffix string
+		size   time.Duration
+	}{{"d", 24 * time.Hour}, {"w", 7 * 24 * time.Hour}} {
+		if strings.HasSuffix(value, unit.suffix) {
+			if n, err := strconv.Atoi(strings.TrimSuffix(value, unit.suffix)); err == nil && n >= 0 {
+				return now.Add(-time.Duration(n) * unit.size), nil
+			}
+		}
+	}
+	if d, err := time.ParseDuration(value); err == nil && d >= 0 {
+		return now.Add(-d), nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q: use a duration like 24h, 7d or 2w, a date like 2024-05-01, or an RFC 3339 timestamp", value)
+}
+
+// roundSeconds keeps durations readable in the history file
+func roundSeconds(d time.Duration) float64 {
+	return float64(d.Round(time.Millisecond)) / float64(time.Second)
+}
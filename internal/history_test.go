@@ -0,0 +1,47 @@
+package internal
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTimeBound(t *testing.T) {
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{value: "", want: time.Time{}},
+		{value: "36h", want: now.Add(-36 * time.Hour)},
+		{value: "90m", want: now.Add(-90 * time.Minute)},
+		{value: " 7d ", want: now.AddDate(0, 0, -7)},
+		{value: "2w", want: now.AddDate(0, 0, -14)},
+		{value: "0d", want: now},
+		{value: "2024-05-01", want: time.Date(2024, 5, 1, 0, 0, 0, 0, time.Local)},
+		{value: "2024-05-01T08:30:00Z", want: time.Date(2024, 5, 1, 8, 30, 0, 0, time.UTC)},
+		{value: "-1h", wantErr: true},
+		{value: "-3d", wantErr: true},
+		{value: "yesterday", wantErr: true},
+		{value: "2024-13-01", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseTimeBound(tt.value, now)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ParseTimeBound(%q) = %s, want an error", tt.value, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseTimeBound(%q): %v", tt.value, err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("ParseTimeBound(%q) = %s, want %s", tt.value, got, tt.want)
+			}
+		})
+	}
+}
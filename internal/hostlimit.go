@@ -0,0 +1,142 @@
+package internal
+
+import (
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// HostLimits controls how hard syncx hits each git server
+type HostLimits struct {
+	PerHost           map[string]int // Maximum concurrent git network commands per host ("*" applies to unlisted hosts)
+	RequestsPerMinute int            // Maximum git network commands started per minute and host (0 = unlimited)
+}
+
+// hostLimiter tracks the concurrency slots and request pacing of a single host
+type hostLimiter struct {
+	slots    chan struct{} // nil when concurrency is unlimited
+	mu       sync.Mutex
+	nextSlot time.Time // Earliest time the next request may start
+}
+
+var (
+	hostLimits   HostLimits
+	hostLimiters = make(map[string]*hostLimiter)
+	hostMu       sync.Mutex
+)
+
+// SetHostLimits configures per-host concurrency and rate limits for all network git operations
+func SetHostLimits(limits HostLimits) {
+	hostMu.Lock()
+	defer hostMu.Unlock()
+
+	normalized := make(map[string]int)
+	for host, limit := range limits.PerHost {
+		normalized[strings.ToLower(host)] = limit
+	}
+	limits.PerHost = normalized
+
+	hostLimits = limits
+	hostLimiters = make(map[string]*hostLimiter)
+}
+
+// limiterForHost returns the limiter of a host, creating it on first use
+func limiterForHost(host string) *hostLimiter {
+	hostMu.Lock()
+	defer hostMu.Unlock()
+
+	if limiter, ok := hostLimiters[host]; ok {
+		return limiter
+	}
+
+	limiter := &hostLimiter{}
+	limit, ok := hostLimits.PerHost[host]
+	if !ok {
+		limit = hostLimits.PerHost["*"]
+	}
+	if limit > 0 {
+		limiter.slots = make(chan struct{}, limit)
+	}
+	hostLimiters[host] = limiter
+	return limiter
+}
+
+// acquireHost blocks until a request to the host is allowed and returns the release function
+func acquireHost(host string) func() {
+	limiter := limiterForHost(host)
+
+	if limiter.slots != nil {
+		limiter.slots <- struct{}{}
+	}
+
+	if rpm := hostLimits.RequestsPerMinute; rpm > 0 {
+		interval := time.Minute / time.Duration(rpm)
+
+		limiter.mu.Lock()
+		now := time.Now()
+		start := limiter.nextSlot
+		if start.Before(now) {
+			start = now
+		}
+		limiter.nextSlot = start.Add(interval)
+		limiter.mu.Unlock()
+
+		time.Sleep(time.Until(start))
+	}
+
+	return func() {
+		if limiter.slots != nil {
+			<-limiter.slots
+		}
+	}
+}
+
+// ExtractHost returns the host name of a git remote URL
+// Supports "git@host:path", "host:path", "https://host/path" and "ssh://user@host:port/path"
+func ExtractHost(remoteURL string) string {
+	if strings.Contains(remoteURL, "://") {
+		if parsed, err := url.Parse(remoteURL); err == nil {
+			return strings.ToLower(parsed.Hostname())
+		}
+		return ""
+	}
+
+	host := remoteURL
+	if at := strings.Index(host, "@"); at >= 0 {
+		host = host[at+1:]
+	}
+	if colon := strings.Index(host, ":"); colon >= 0 {
+		host = host[:colon]
+	} else if slash := strings.Index(host, "/"); slash >= 0 {
+		host = host[:slash]
+	}
+	return strings.ToLower(host)
+}
+
+// hostForGitArgs determines which remote host a git command will talk to
+func hostForGitArgs(args []string) string {
+	for i, arg := range args {
+		// git clone [options] <url> <path>
+		if arg == "clone" && len(args) >= 2 {
+			return ExtractHost(args[len(args)-2])
+		}
+		// git -C <path> fetch/pull: ask the repository for its origin
+		if arg == "-C" && i+1 < len(args) {
+			output, err := RunGitCommand("-C", args[i+1], "config", "--get", "remote.origin.url")
+			if err != nil {
+				return ""
+			}
+			return ExtractHost(strings.TrimSpace(string(output)))
+		}
+	}
+	return ""
+}
+
+// runGitRemoteCommand runs a single git network command within the limits of its remote host
+func runGitRemoteCommand(timeout time.Duration, args ...string) ([]byte, string, error) {
+	release := acquireHost(hostForGitArgs(args))
+	defer release()
+
+	return runGitCommandCapture(timeout, args...)
+}
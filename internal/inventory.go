@@ -6,6 +6,9 @@ import (
 	"io/ioutil"
 	"os"
 	"strings"
+	"time"
+
+	"github.com/fatih/color"
 )
 
 // LoadInventory loads and parses the inventory JSON file
@@ -15,16 +18,136 @@ func LoadInventory(filename string) (*Inventory, error) {
 		return nil, fmt.Errorf("failed to read %s: %w", filename, err)
 	}
 
+	migrated, version, err := migrateDocument(filename, data, InventorySchemaVersion, inventoryMigrations)
+	if err != nil {
+		if _, newer := err.(*SchemaVersionError); newer {
+			return nil, err
+		}
+		return nil, fmt.Errorf("invalid JSON in %s: %w", filename, err)
+	}
+	if version < InventorySchemaVersion && persistMigrations {
+		// Upgrade the file once; an inventory that cannot be written is still usable in memory
+		if backupPath, err := persistMigration(filename, data, migrated, version); err != nil {
+			color.New(color.FgYellow).Printf("⚠️  Could not save the migrated inventory: %v\n", err)
+		} else {
+			color.New(color.FgCyan).Printf("ℹ️  Migrated %s to schema version %d (backup: %s)\n", filename, InventorySchemaVersion, backupPath)
+			FileLog().Info("inventory migrated", "file", filename, "from", version, "to", InventorySchemaVersion, "backup", backupPath)
+		}
+	}
+
 	var inventory Inventory
-	if err := json.Unmarshal(data, &inventory); err != nil {
+	if err := json.Unmarshal(migrated, &inventory); err != nil {
 		return nil, fmt.Errorf("invalid JSON in %s: %w", filename, err)
 	}
+	if inventory.PhysicalLocation == "" {
+		inventory.PhysicalLocation = inventory.LegacyPhysicalLocation
+	}
+	inventory.LegacyPhysicalLocation = ""
+
+	if err := validateProjectTimeouts(inventory); err != nil {
+		return nil, fmt.Errorf("invalid inventory %s: %w", filename, err)
+	}
+	for _, warning := range sharedRepositoryWarnings(inventory) {
+		color.New(color.FgYellow).Printf("⚠️  %s\n", warning)
+		FileLog().Warn("inventory lists a repository twice", "file", filename, "detail", warning)
+	}
 
 	return &inventory, nil
 }
 
+// sharedRepositoryWarnings describes projects with the identity of an earlier project: they
+// would share one tracker entry and one clone directory, so CollectAllProjects drops them
+func sharedRepositoryWarnings(inventory Inventory) []string {
+	var warnings []string
+	seen := make(map[string]ProjectInfo)
+	for _, project := range collectInventoryProjects(inventory) {
+		identity := ProjectIdentity(project)
+		if first, ok := seen[identity]; ok {
+			warnings = append(warnings, fmt.Sprintf("Projects %s (%s) and %s (%s) are the same repository %s; only %s is used",
+				first.Name, first.Group, project.Name, project.Group, identity, first.Name))
+			continue
+		}
+		seen[identity] = project
+	}
+	return warnings
+}
+
+// validateProjectTimeouts makes sure per-project timeout overrides are valid durations
+func validateProjectTimeouts(inventory Inventory) error {
+	check := func(project Project) error {
+		for field, value := range map[string]string{"clone_timeout": project.CloneTimeout, "fetch_timeout": project.FetchTimeout} {
+			if value == "" {
+				continue
+			}
+			if d, err := time.ParseDuration(value); err != nil || d <= 0 {
+				return fmt.Errorf("project %s has invalid %s %q (use e.g. \"90s\" or \"10m\")", project.Name, field, value)
+			}
+		}
+		return nil
+	}
+
+	var walk func(groups []Group) error
+	walk = func(groups []Group) error {
+		for _, group := range groups {
+			for _, project := range group.Projects {
+				if err := check(project); err != nil {
+					return err
+				}
+			}
+			if err := walk(group.Groups); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	groups, projects := inventory.Groups, inventory.Projects
+	if inventory.Root != nil {
+		groups, projects = inventory.Root.Groups, inventory.Root.Projects
+	}
+	for _, project := range projects {
+		if err := check(project); err != nil {
+			return err
+		}
+	}
+	return walk(groups)
+}
+
+// newProjectInfo builds the runtime project information for an inventory project
+func newProjectInfo(project Project, group string) ProjectInfo {
+	info := ProjectInfo{
+		ID:     project.ID,
+		Name:   project.Name,
+		URL:    project.URL,
+		Group:  group,
+		Branch: project.Branch,
+	}
+	// Overrides were validated when the inventory was loaded
+	if d, err := time.ParseDuration(project.CloneTimeout); err == nil {
+		info.CloneTimeout = d
+	}
+	if d, err := time.ParseDuration(project.FetchTimeout); err == nil {
+		info.FetchTimeout = d
+	}
+	return info
+}
+
 // CollectAllProjects recursively collects all projects from inventory structure
+// A repository listed more than once is kept as its first project only
 func CollectAllProjects(inventory Inventory) []ProjectInfo {
+	var unique []ProjectInfo
+	seen := make(map[string]bool)
+	for _, project := range collectInventoryProjects(inventory) {
+		if identity := ProjectIdentity(project); !seen[identity] {
+			seen[identity] = true
+			unique = append(unique, project)
+		}
+	}
+	return unique
+}
+
+// collectInventoryProjects walks the groups of the inventory, skipping exact duplicates
+func collectInventoryProjects(inventory Inventory) []ProjectInfo {
 	var allProjects []ProjectInfo
 	projectsFound := make(map[string]bool) // Track duplicates
 
@@ -46,11 +169,7 @@ func CollectAllProjects(inventory Inventory) []ProjectInfo {
 					// Create unique key to avoid duplicates
 					projectKey := fmt.Sprintf("%s|%s", project.Name, project.URL)
 					if !projectsFound[projectKey] {
-						allProjects = append(allProjects, ProjectInfo{
-							Name:  project.Name,
-							URL:   project.URL,
-							Group: groupName,
-						})
+						allProjects = append(allProjects, newProjectInfo(project, groupName))
 						projectsFound[projectKey] = true
 					}
 				}
@@ -83,11 +202,7 @@ func CollectAllProjects(inventory Inventory) []ProjectInfo {
 		if project.URL != "" && project.Name != "" {
 			projectKey := fmt.Sprintf("%s|%s", project.Name, project.URL)
 			if !projectsFound[projectKey] {
-				allProjects = append(allProjects, ProjectInfo{
-					Name:  project.Name,
-					URL:   project.URL,
-					Group: "Standalone",
-				})
+				allProjects = append(allProjects, newProjectInfo(project, "Standalone"))
 				projectsFound[projectKey] = true
 			}
 		}
@@ -167,10 +282,20 @@ func ScanAndClassifyProjectsWithTrackingSkipCheck(allProjects []ProjectInfo, bas
 		}
 	}
 
-	// Process modified projects
+	// Process modified projects: their clone was not relocated (collision or dry run), so it
+	// keeps being used at its tracked path; only a clone that exists nowhere is cloned again
 	for _, project := range diff.ModifiedProjects {
 		logger.Info("🔄 Modified project detected: %s", project.Name)
-		projectsToUpdate = append(projectsToUpdate, project)
+		previous := diff.PreviousPaths[ProjectIdentity(project)]
+		switch {
+		case previous != "" && IsGitRepository(previous):
+			project.LocalPath = previous
+			projectsToUpdate = append(projectsToUpdate, project)
+		case IsGitRepository(project.LocalPath):
+			projectsToUpdate = append(projectsToUpdate, project)
+		default:
+			projectsToClone = append(projectsToClone, project)
+		}
 	}
 
 	// Process unchanged projects (check for git changes)
@@ -216,7 +341,13 @@ func ScanAndClassifyProjectsWithTrackingSkipCheck(allProjects []ProjectInfo, bas
 	}
 
 	// Handle removed projects
+	// Removed projects stay tracked while their clone exists, so 'syncx prune' can find them
 	for _, project := range diff.RemovedProjects {
+		if IsGitRepository(project.LocalPath) {
+			logger.Info("➖ Project removed from inventory: %s (clone kept at %s, see 'syncx prune')", project.Name, project.LocalPath)
+			markTrackedProjectOrphaned(tracker, project)
+			continue
+		}
 		logger.Info("➖ Project removed from inventory: %s", project.Name)
 		RemoveTrackedProject(tracker, project)
 	}
@@ -295,6 +426,8 @@ func UpdatePhysicalLocation(inventoryPath, newLocation string) error {
 
 // SaveInventory saves the inventory structure back to JSON file with proper formatting
 func SaveInventory(inventoryPath string, inventory *Inventory) error {
+	inventory.SchemaVersion = InventorySchemaVersion
+
 	// Convert to JSON with proper indentation
 	jsonData, err := json.MarshalIndent(inventory, "", "  ")
 	if err != nil {
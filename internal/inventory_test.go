@@ -0,0 +1,33 @@
+package internal
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCollectAllProjectsSharedRepositories(t *testing.T) {
+	inventory := Inventory{Groups: []Group{
+		{Name: "Backend", Projects: []Project{
+			{Name: "api", URL: "gitlab.com:olive/api.git"},
+			{Name: "api", URL: "gitlab.com:olive/api.git"},
+			{Name: "auth", URL: "gitlab.com:olive/auth.git"},
+		}},
+		{Name: "Mirror", Projects: []Project{
+			{Name: "api-mirror", URL: "https://gitlab.com/olive/api"},
+			{Name: "auth-fork", ID: "auth-fork", URL: "gitlab.com:olive/auth.git"},
+		}},
+	}}
+
+	var names []string
+	for _, project := range CollectAllProjects(inventory) {
+		names = append(names, project.Group+"/"+project.Name)
+	}
+	if got, want := strings.Join(names, ","), "Backend/api,Backend/auth,Mirror/auth-fork"; got != want {
+		t.Errorf("projects = %s, want %s", got, want)
+	}
+
+	warnings := sharedRepositoryWarnings(inventory)
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "api-mirror") || !strings.Contains(warnings[0], "gitlab.com/olive/api") {
+		t.Errorf("warnings = %q, want one about api-mirror", warnings)
+	}
+}
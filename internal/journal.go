@@ -0,0 +1,218 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const JournalFileName = ".olive-clone-journal.json"
+
+// Journal job states
+const (
+	JobPending = "pending"
+	JobDone    = "done"
+	JobFailed  = "failed"
+)
+
+// NewRunJournal creates a journal with one pending job per project
+func NewRunJournal(command, outputDir, inventoryFile, protocol string, parallel int, group string, projects []ProjectInfo, isClone bool) *RunJournal {
+	now := time.Now().Format(time.RFC3339)
+	journal := &RunJournal{
+		Command:         command,
+		StartedAt:       now,
+		UpdatedAt:       now,
+		OutputDirectory: outputDir,
+		InventoryFile:   inventoryFile,
+		Protocol:        protocol,
+		Parallel:        parallel,
+		Group:           group,
+		Jobs:            make([]JournalJob, 0, len(projects)),
+	}
+
+	for _, project := range projects {
+		journal.Jobs = append(journal.Jobs, JournalJob{
+			ID:        project.ID,
+			Name:      project.Name,
+			URL:       project.URL,
+			Group:     project.Group,
+			GitURL:    project.GitURL,
+			LocalPath: project.LocalPath,
+			IsClone:   isClone,
+			State:     JobPending,
+
+			CloneTimeout: formatTimeout(project.CloneTimeout),
+			FetchTimeout: formatTimeout(project.FetchTimeout),
+		})
+	}
+
+	return journal
+}
+
+// LoadJournal loads the run journal from the output directory
+// Returns nil without error when no interrupted run exists
+func LoadJournal(outputDir string) (*RunJournal, error) {
+	journalPath := filepath.Join(outputDir, JournalFileName)
+
+	data, err := os.ReadFile(journalPath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read run journal: %w", err)
+	}
+
+	var journal RunJournal
+	if err := json.Unmarshal(data, &journal); err != nil {
+		return nil, fmt.Errorf("failed to parse run journal: %w", err)
+	}
+
+	return &journal, nil
+}
+
+// SaveJournal writes the journal to disk
+func SaveJournal(journal *RunJournal) error {
+	if journal == nil {
+		return nil
+	}
+
+	journal.mu.Lock()
+	defer journal.mu.Unlock()
+
+	return saveJournalLocked(journal)
+}
+
+// saveJournalLocked writes the journal; the caller must hold journal.mu
+func saveJournalLocked(journal *RunJournal) error {
+	journal.UpdatedAt = time.Now().Format(time.RFC3339)
+
+	data, err := json.MarshalIndent(journal, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal run journal: %w", err)
+	}
+
+	// Write to a temporary file first so an interruption never leaves a truncated journal
+	journalPath := filepath.Join(journal.OutputDirectory, JournalFileName)
+	tmpPath := journalPath + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write run journal: %w", err)
+	}
+	if err := os.Rename(tmpPath, journalPath); err != nil {
+		return fmt.Errorf("failed to write run journal: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveJournal deletes the run journal once a run has finished
+func RemoveJournal(outputDir string) error {
+	journalPath := filepath.Join(outputDir, JournalFileName)
+	if err := os.Remove(journalPath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove run journal: %w", err)
+	}
+	return nil
+}
+
+// MarkJournalJob records the outcome of a job and persists the journal
+// Safe to call concurrently and with a nil journal (e.g. in dry-run mode)
+func MarkJournalJob(journal *RunJournal, result OperationResult) {
+	if journal == nil {
+		return
+	}
+
+	journal.mu.Lock()
+	defer journal.mu.Unlock()
+
+	identity := ProjectIdentity(result.Project)
+	for i, job := range journal.Jobs {
+		if ProjectIdentity(ProjectInfo{ID: job.ID, URL: job.URL}) != identity {
+			continue
+		}
+		if result.Success {
+			journal.Jobs[i].State = JobDone
+		} else {
+			journal.Jobs[i].State = JobFailed
+		}
+		journal.Jobs[i].Message = result.Message
+		break
+	}
+
+	// Best effort: a failed write only means a resume repeats some work
+	saveJournalLocked(journal)
+}
+
+// UnfinishedJournalProjects returns the projects whose jobs never completed
+func UnfinishedJournalProjects(journal *RunJournal) []ProjectInfo {
+	var projects []ProjectInfo
+	for _, job := range journal.Jobs {
+		if job.State != JobPending {
+			continue
+		}
+		project := ProjectInfo{
+			ID:        job.ID,
+			Name:      job.Name,
+			URL:       job.URL,
+			GitURL:    job.GitURL,
+			LocalPath: job.LocalPath,
+			Group:     job.Group,
+		}
+		project.CloneTimeout, _ = time.ParseDuration(job.CloneTimeout)
+		project.FetchTimeout, _ = time.ParseDuration(job.FetchTimeout)
+		projects = append(projects, project)
+	}
+	return projects
+}
+
+// formatTimeout stores a resolved timeout in the journal, leaving defaults empty
+func formatTimeout(timeout time.Duration) string {
+	if timeout <= 0 {
+		return ""
+	}
+	return timeout.String()
+}
+
+// CleanPartialClones removes directories left behind by clones that were interrupted
+// A clone that actually finished (valid repository with commits) is kept and marked done
+// Returns the number of directories removed
+func CleanPartialClones(journal *RunJournal, logger *Logger) int {
+	journal.mu.Lock()
+	defer journal.mu.Unlock()
+
+	outputDir := filepath.Clean(journal.OutputDirectory) + string(filepath.Separator)
+	removed := 0
+
+	for i, job := range journal.Jobs {
+		if !job.IsClone || job.State != JobPending {
+			continue
+		}
+		if _, err := os.Stat(job.LocalPath); err != nil {
+			continue
+		}
+
+		// Never touch anything outside the output directory
+		if !strings.HasPrefix(filepath.Clean(job.LocalPath), outputDir) {
+			logger.Warning("Skipping cleanup of %s: outside output directory", job.LocalPath)
+			continue
+		}
+
+		if IsGitRepository(job.LocalPath) && !IsEmptyRepository(job.LocalPath) {
+			logger.Info("Found completed clone for %s, marking as done", job.Name)
+			journal.Jobs[i].State = JobDone
+			journal.Jobs[i].Message = "Clone completed before interruption"
+			continue
+		}
+
+		logger.Warning("Removing partial clone: %s", job.LocalPath)
+		if err := os.RemoveAll(job.LocalPath); err != nil {
+			logger.Error("Failed to remove partial clone %s: %v", job.LocalPath, err)
+			continue
+		}
+		removed++
+	}
+
+	saveJournalLocked(journal)
+	return removed
+}
@@ -0,0 +1,166 @@
+package internal
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// JUnit test case outcomes
+const (
+	JUnitPassed  = "passed"
+	JUnitFailure = "failure"
+	JUnitSkipped = "skipped"
+)
+
+// JUnitTestSuites is the root element of a JUnit XML report
+type JUnitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []JUnitTestSuite `xml:"testsuite"`
+}
+
+// JUnitTestSuite groups the test cases of one command run
+type JUnitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Time      string          `xml:"time,attr"`
+	Timestamp string          `xml:"timestamp,attr"`
+	Cases     []JUnitTestCase `xml:"testcase"`
+}
+
+// JUnitTestCase is one repository
+type JUnitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *JUnitMessage `xml:"failure,omitempty"`
+	Skipped   *JUnitMessage `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+// JUnitMessage is the body of a failure or skipped element
+type JUnitMessage struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr,omitempty"`
+	Text    string `xml:",chardata"`
+}
+
+// ValidateJUnitOutcome checks a configured outcome for dirty or behind repositories
+func ValidateJUnitOutcome(outcome string) error {
+	if outcome != JUnitFailure && outcome != JUnitSkipped {
+		return fmt.Errorf("invalid JUnit outcome: %s. Must be 'failure' or 'skipped'", outcome)
+	}
+	return nil
+}
+
+// NewJUnitTestSuite creates an empty suite, e.g. "syncx.status"
+func NewJUnitTestSuite(name string) *JUnitTestSuite {
+	return &JUnitTestSuite{
+		Name:      name,
+		Timestamp: time.Now().Format("2006-01-02T15:04:05"),
+	}
+}
+
+// AddCase records one repository with its outcome
+// className is usually the inventory group, so dashboards group repositories the same way
+func (s *JUnitTestSuite) AddCase(name, className string, duration time.Duration, outcome, failureType, message string) {
+	testCase := JUnitTestCase{
+		Name:      name,
+		ClassName: className,
+		Time:      junitSeconds(duration),
+	}
+
+	switch outcome {
+	case JUnitFailure:
+		testCase.Failure = &JUnitMessage{Message: firstLine(message), Type: failureType, Text: message}
+		s.Failures++
+	case JUnitSkipped:
+		testCase.Skipped = &JUnitMessage{Message: message}
+		s.Skipped++
+	default:
+		testCase.SystemOut = message
+	}
+
+	s.Cases = append(s.Cases, testCase)
+	s.Tests++
+}
+
+// AddSummary adds one test case per clone or pull result
+// Failed operations are failures, empty repositories are skipped
+func (s *JUnitTestSuite) AddSummary(summary Summary) {
+	for _, result := range summary.Results {
+		duration, _ := time.ParseDuration(result.Duration)
+		operation := "pull"
+		if result.IsClone {
+			operation = "clone"
+		}
+
+		switch {
+		case result.IsEmpty:
+			s.AddCase(result.Project.Name, JUnitClassName(result.Project.Group), duration, JUnitSkipped, "", result.Message)
+		case !result.Success:
+			s.AddCase(result.Project.Name, JUnitClassName(result.Project.Group), duration, JUnitFailure, operation, result.Message)
+		default:
+			s.AddCase(result.Project.Name, JUnitClassName(result.Project.Group), duration, JUnitPassed, "", result.Message)
+		}
+	}
+}
+
+// WriteJUnit writes the suite as a JUnit XML file
+func WriteJUnit(path string, suite *JUnitTestSuite, duration time.Duration) error {
+	suite.Time = junitSeconds(duration)
+	suites := JUnitTestSuites{
+		Name:     suite.Name,
+		Tests:    suite.Tests,
+		Failures: suite.Failures,
+		Errors:   suite.Errors,
+		Skipped:  suite.Skipped,
+		Time:     suite.Time,
+		Suites:   []JUnitTestSuite{*suite},
+	}
+
+	data, err := xml.MarshalIndent(suites, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to render JUnit report: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create JUnit report directory: %w", err)
+	}
+	content := append([]byte(xml.Header), data...)
+	if err := os.WriteFile(path, append(content, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write JUnit report: %w", err)
+	}
+	return nil
+}
+
+// JUnitClassName maps an inventory group to a JUnit class name
+func JUnitClassName(group string) string {
+	if group == "" {
+		return "syncx"
+	}
+	return "syncx." + group
+}
+
+func junitSeconds(duration time.Duration) string {
+	return fmt.Sprintf("%.3f", duration.Seconds())
+}
+
+func firstLine(message string) string {
+	if i := strings.IndexByte(message, '\n'); i >= 0 {
+		return message[:i]
+	}
+	return message
+}
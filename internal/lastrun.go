@@ -0,0 +1,75 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const LastRunFileName = ".olive-clone-last-run.json"
+
+// SaveLastRun stores the results of a finished run next to the tracker
+func SaveLastRun(outputDir, command, inventoryFile string, startedAt time.Time, summary Summary) error {
+	lastRun := LastRun{
+		Command:         command,
+		StartedAt:       startedAt.Format(time.RFC3339),
+		FinishedAt:      time.Now().Format(time.RFC3339),
+		OutputDirectory: outputDir,
+		InventoryFile:   inventoryFile,
+		Summary:         summary,
+	}
+
+	data, err := json.MarshalIndent(lastRun, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal last run: %w", err)
+	}
+
+	lastRunPath := filepath.Join(outputDir, LastRunFileName)
+	if err := os.WriteFile(lastRunPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write last run file: %w", err)
+	}
+
+	return nil
+}
+
+// LoadLastRun loads the results of the previous run
+// Returns nil without error when no run has been recorded yet
+func LoadLastRun(outputDir string) (*LastRun, error) {
+	lastRunPath := filepath.Join(outputDir, LastRunFileName)
+
+	data, err := os.ReadFile(lastRunPath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read last run file: %w", err)
+	}
+
+	var lastRun LastRun
+	if err := json.Unmarshal(data, &lastRun); err != nil {
+		return nil, fmt.Errorf("failed to parse last run file: %w", err)
+	}
+
+	return &lastRun, nil
+}
+
+// FilterProjectsToRetry keeps only the projects that failed or were empty in the given run
+func FilterProjectsToRetry(projects []ProjectInfo, lastRun *LastRun) []ProjectInfo {
+	retryKeys := make(map[string]bool)
+	for _, project := range lastRun.Summary.FailedProjects {
+		retryKeys[ProjectIdentity(project)] = true
+	}
+	for _, project := range lastRun.Summary.EmptyProjects {
+		retryKeys[ProjectIdentity(project)] = true
+	}
+
+	var filtered []ProjectInfo
+	for _, project := range projects {
+		if retryKeys[ProjectIdentity(project)] {
+			filtered = append(filtered, project)
+		}
+	}
+	return filtered
+}
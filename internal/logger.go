@@ -2,6 +2,7 @@ package internal
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/briandowns/spinner"
@@ -9,6 +10,7 @@ import (
 )
 
 // Logger handles colored output based on verbose mode
+// Messages are also written to the log file, whatever the verbosity
 type Logger struct {
 	verbose bool
 }
@@ -20,6 +22,7 @@ func NewLogger(verbose bool) *Logger {
 
 // Info logs info messages (only in verbose mode)
 func (l *Logger) Info(format string, args ...interface{}) {
+	fileLog.Info(fmt.Sprintf(format, args...))
 	if l.verbose {
 		color.New(color.FgCyan).Printf("ℹ️  "+format+"\n", args...)
 	}
@@ -27,21 +30,25 @@ func (l *Logger) Info(format string, args ...interface{}) {
 
 // Success logs success messages
 func (l *Logger) Success(format string, args ...interface{}) {
+	fileLog.Info(fmt.Sprintf(format, args...))
 	color.New(color.FgGreen, color.Bold).Printf("✅ "+format+"\n", args...)
 }
 
 // Warning logs warning messages
 func (l *Logger) Warning(format string, args ...interface{}) {
+	fileLog.Warn(fmt.Sprintf(format, args...))
 	color.New(color.FgYellow, color.Bold).Printf("⚠️  "+format+"\n", args...)
 }
 
 // Error logs error messages
 func (l *Logger) Error(format string, args ...interface{}) {
+	fileLog.Error(fmt.Sprintf(format, args...))
 	color.New(color.FgRed, color.Bold).Printf("❌ "+format+"\n", args...)
 }
 
 // Debug logs debug messages (only in verbose mode)
 func (l *Logger) Debug(format string, args ...interface{}) {
+	fileLog.Debug(fmt.Sprintf(format, args...))
 	if l.verbose {
 		color.New(color.FgWhite, color.Faint).Printf("🐛 "+format+"\n", args...)
 	}
@@ -104,8 +111,12 @@ func (l *Logger) Separator() {
 	color.New(color.FgBlue).Println("─────────────────────────────────────────────────")
 }
 
-// Banner prints the application banner
+// Banner prints the application banner (skipped in plain mode)
 func (l *Logger) Banner() {
+	if plainOutput {
+		return
+	}
+
 	banner := `
  ███████╗██╗   ██╗███╗   ██╗ ██████╗██╗  ██╗
  ██╔════╝╚██╗ ██╔╝████╗  ██║██╔════╝╚██╗██╔╝
@@ -146,6 +157,10 @@ func (l *Logger) Summary(summary Summary) {
 		color.New(color.FgYellow).Printf("📭 Empty: %d\n", summary.EmptyCount)
 	}
 
+	if summary.RetriedCount > 0 {
+		color.New(color.FgYellow).Printf("🔁 Succeeded after retry: %d\n", summary.RetriedCount)
+	}
+
 	if summary.TotalDuration != "" {
 		color.New(color.FgCyan, color.Bold).Printf("⏱️  Duration: %s\n", summary.TotalDuration)
 	}
@@ -158,6 +173,14 @@ func (l *Logger) Summary(summary Summary) {
 		}
 	}
 
+	if len(summary.RetriedProjects) > 0 {
+		fmt.Println()
+		color.New(color.FgYellow).Println("Succeeded Only After Retrying:")
+		for _, project := range summary.RetriedProjects {
+			color.New(color.FgYellow).Printf("  • %s (%s)\n", project.Name, project.Group)
+		}
+	}
+
 	if len(summary.FailedProjects) > 0 {
 		fmt.Println()
 		color.New(color.FgRed, color.Bold).Println("Failed Projects:")
@@ -181,8 +204,13 @@ func (l *Logger) NewSpinner(message string) *spinner.Spinner {
 }
 
 // StartSpinner creates and starts a spinner
+// In plain mode the message is printed once and the spinner is never started
 func (l *Logger) StartSpinner(message string) *spinner.Spinner {
 	s := l.NewSpinner(message)
+	if plainOutput {
+		color.New(color.FgCyan).Println(strings.TrimSuffix(message, "...") + "...")
+		return s
+	}
 	s.Start()
 	return s
 }
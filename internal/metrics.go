@@ -0,0 +1,247 @@
+package internal
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Metric types in the Prometheus text format
+const (
+	MetricGauge   = "gauge"
+	MetricCounter = "counter"
+)
+
+// Metrics collects samples for the Prometheus node_exporter textfile collector
+type Metrics struct {
+	command  string
+	families map[string]*metricFamily
+	order    []string
+	previous []*metricFamily // Families of the last file, so counters keep increasing across runs
+}
+
+type metricFamily struct {
+	name    string
+	help    string
+	kind    string
+	samples []metricSample
+}
+
+type metricSample struct {
+	labels string
+	value  float64
+}
+
+// NewMetrics creates the metrics of one command run
+// Counters continue from the values already in path, and the series this run
+// does not write again are kept so clone, pull and status can share a file
+func NewMetrics(path, command string) *Metrics {
+	return &Metrics{
+		command:  command,
+		families: make(map[string]*metricFamily),
+		previous: readMetricFamilies(path),
+	}
+}
+
+// Gauge sets a gauge sample; labels are name/value pairs
+func (m *Metrics) Gauge(name, help string, value float64, labels ...string) {
+	m.add(name, help, MetricGauge, value, labels)
+}
+
+// Counter increases a counter sample by delta, starting from its value in the previous file
+func (m *Metrics) Counter(name, help string, delta float64, labels ...string) {
+	value := delta
+	if previous := m.previousSample(name, formatMetricLabels(labels)); previous != nil {
+		value += previous.value
+	}
+	m.add(name, help, MetricCounter, value, labels)
+}
+
+func (m *Metrics) previousSample(name, labels string) *metricSample {
+	for _, family := range m.previous {
+		if family.name != name {
+			continue
+		}
+		for i := range family.samples {
+			if family.samples[i].labels == labels {
+				return &family.samples[i]
+			}
+		}
+	}
+	return nil
+}
+
+func (m *Metrics) add(name, help, kind string, value float64, labels []string) {
+	family, ok := m.families[name]
+	if !ok {
+		family = &metricFamily{name: name, help: help, kind: kind}
+		m.families[name] = family
+		m.order = append(m.order, name)
+	}
+	family.samples = append(family.samples, metricSample{labels: formatMetricLabels(labels), value: value})
+}
+
+// AddRun adds the duration, finish time and run count of the command
+func (m *Metrics) AddRun(duration time.Duration) {
+	m.Gauge("syncx_run_duration_seconds", "Duration of the last run", duration.Seconds(), "command", m.command)
+	m.Gauge("syncx_run_timestamp_seconds", "Unix time the last run finished", float64(time.Now().Unix()), "command", m.command)
+	m.Counter("syncx_runs_total", "Number of runs", 1, "command", m.command)
+}
+
+// AddSummary adds the run-level counters and the per-project outcome of a clone or pull run
+func (m *Metrics) AddSummary(summary Summary) {
+	duration, _ := time.ParseDuration(summary.TotalDuration)
+	m.AddRun(duration)
+	m.Counter("syncx_repositories_cloned_total", "Repositories cloned", float64(summary.ClonedCount), "command", m.command)
+	m.Counter("syncx_repositories_updated_total", "Repositories updated", float64(summary.UpdatedCount), "command", m.command)
+	m.Counter("syncx_repositories_failed_total", "Repository operations that failed", float64(summary.FailureCount), "command", m.command)
+	m.Counter("syncx_repositories_empty_total", "Empty repositories found", float64(summary.EmptyCount), "command", m.command)
+
+	m.AddOperationResults(summary.Results)
+}
+
+// AddOperationResults adds whether the last clone or pull of each project succeeded
+func (m *Metrics) AddOperationResults(results []OperationResult) {
+	for _, result := range results {
+		success := 0.0
+		if result.Success {
+			success = 1
+		}
+		m.Gauge("syncx_repo_last_operation_success", "1 if the last clone or pull of the repository succeeded",
+			success, "project", result.Project.Name, "group", result.Project.Group)
+	}
+}
+
+// AddTrackerAges adds the seconds since each tracked project was last synced successfully
+func (m *Metrics) AddTrackerAges(outputDir, inventoryFile string) {
+	tracker, err := LoadOrCreateTracker(outputDir, inventoryFile)
+	if err != nil {
+		return
+	}
+
+	for _, tracked := range tracker.Projects {
+		lastSync, err := time.Parse(time.RFC3339, tracked.LastUpdated)
+		if err != nil {
+			continue
+		}
+		m.Gauge("syncx_repo_last_sync_age_seconds", "Seconds since the last successful clone or pull of the repository",
+			time.Since(lastSync).Seconds(), "project", tracked.Name, "group", tracked.Group)
+	}
+}
+
+// WriteMetrics writes the metrics atomically so the collector never reads a partial file
+func WriteMetrics(path string, metrics *Metrics) error {
+	metrics.keepPreviousSamples()
+
+	var b strings.Builder
+	for _, name := range metrics.order {
+		family := metrics.families[name]
+		fmt.Fprintf(&b, "# HELP %s %s\n", family.name, family.help)
+		fmt.Fprintf(&b, "# TYPE %s %s\n", family.name, family.kind)
+		for _, sample := range family.samples {
+			fmt.Fprintf(&b, "%s%s %s\n", family.name, sample.labels, strconv.FormatFloat(sample.value, 'f', -1, 64))
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create metrics directory: %w", err)
+	}
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, []byte(b.String()), 0644); err != nil {
+		return fmt.Errorf("failed to write metrics file: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("failed to write metrics file: %w", err)
+	}
+	return nil
+}
+
+// formatMetricLabels renders name/value pairs as {a="1",b="2"}, sorted by name
+func formatMetricLabels(labels []string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+
+	pairs := make([]string, 0, len(labels)/2)
+	for i := 0; i+1 < len(labels); i += 2 {
+		value := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(labels[i+1])
+		pairs = append(pairs, fmt.Sprintf(`%s="%s"`, labels[i], value))
+	}
+	sort.Strings(pairs)
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+// keepPreviousSamples carries over the previous samples this run did not write again: the
+// run-level series of other commands, and the per-repository series of repositories that
+// were not part of this run (a --group subset, a clone with nothing to do, another command)
+func (m *Metrics) keepPreviousSamples() {
+	for _, previous := range m.previous {
+		written := make(map[string]bool)
+		if family, ok := m.families[previous.name]; ok {
+			for _, sample := range family.samples {
+				written[sample.labels] = true
+			}
+		}
+		for _, sample := range previous.samples {
+			if written[sample.labels] {
+				continue
+			}
+			family, ok := m.families[previous.name]
+			if !ok {
+				family = &metricFamily{name: previous.name, help: previous.help, kind: previous.kind}
+				m.families[previous.name] = family
+				m.order = append(m.order, previous.name)
+			}
+			family.samples = append(family.samples, sample)
+		}
+	}
+}
+
+// readMetricFamilies reads the HELP, TYPE and samples of an existing metrics file
+func readMetricFamilies(path string) []*metricFamily {
+	var families []*metricFamily
+
+	file, err := os.Open(path)
+	if err != nil {
+		return families
+	}
+	defer file.Close()
+
+	var current *metricFamily
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "# HELP ") {
+			fields := strings.SplitN(strings.TrimPrefix(line, "# HELP "), " ", 2)
+			current = &metricFamily{name: fields[0]}
+			if len(fields) == 2 {
+				current.help = fields[1]
+			}
+			families = append(families, current)
+			continue
+		}
+		if strings.HasPrefix(line, "# TYPE ") && current != nil {
+			if fields := strings.Fields(line); len(fields) == 4 {
+				current.kind = fields[3]
+			}
+			continue
+		}
+		if line == "" || strings.HasPrefix(line, "#") || current == nil {
+			continue
+		}
+
+		i := strings.LastIndexByte(line, ' ')
+		if i < 0 || line[:i] == "" || !strings.HasPrefix(line, current.name) {
+			continue
+		}
+		if value, err := strconv.ParseFloat(line[i+1:], 64); err == nil {
+			current.samples = append(current.samples, metricSample{labels: line[len(current.name):i], value: value})
+		}
+	}
+	return families
+}
@@ -0,0 +1,94 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// readSamples returns the samples of a metrics file keyed by name and labels
+func readSamples(t *testing.T, path string) map[string]string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading metrics: %v", err)
+	}
+	samples := make(map[string]string)
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		space := strings.LastIndex(line, " ")
+		samples[line[:space]] = line[space+1:]
+	}
+	return samples
+}
+
+func writeTestMetrics(t *testing.T, path, command string, summary Summary) {
+	t.Helper()
+	metrics := NewMetrics(path, command)
+	metrics.AddSummary(summary)
+	if err := WriteMetrics(path, metrics); err != nil {
+		t.Fatalf("WriteMetrics: %v", err)
+	}
+}
+
+func TestWriteMetricsAcrossRuns(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "textfile", "syncx.prom")
+
+	writeTestMetrics(t, path, "clone", Summary{
+		ClonedCount:   2,
+		TotalDuration: "3s",
+		Results: []OperationResult{
+			{Success: true, Project: ProjectInfo{Name: "api", Group: "Backend"}},
+			{Success: true, Project: ProjectInfo{Name: "app", Group: "Frontend"}},
+		},
+	})
+	writeTestMetrics(t, path, "pull", Summary{
+		FailureCount:  1,
+		TotalDuration: "1s",
+		Results: []OperationResult{
+			{Project: ProjectInfo{Name: "api", Group: "Backend"}},
+		},
+	})
+	writeTestMetrics(t, path, "pull", Summary{TotalDuration: "2s"})
+
+	samples := readSamples(t, path)
+	want := map[string]string{
+		`syncx_runs_total{command="clone"}`:                                 "1",
+		`syncx_runs_total{command="pull"}`:                                  "2",
+		`syncx_run_duration_seconds{command="clone"}`:                       "3",
+		`syncx_run_duration_seconds{command="pull"}`:                        "2",
+		`syncx_repositories_cloned_total{command="clone"}`:                  "2",
+		`syncx_repositories_failed_total{command="pull"}`:                   "1",
+		`syncx_repo_last_operation_success{group="Backend",project="api"}`:  "0",
+		`syncx_repo_last_operation_success{group="Frontend",project="app"}`: "1",
+	}
+	for key, value := range want {
+		if samples[key] != value {
+			t.Errorf("%s = %q, want %q", key, samples[key], value)
+		}
+	}
+
+	if _, err := os.Stat(path + ".tmp"); !os.IsNotExist(err) {
+		t.Errorf("temporary file left behind: %v", err)
+	}
+}
+
+func TestWriteMetricsFormat(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "syncx.prom")
+	metrics := NewMetrics(path, "status")
+	metrics.Gauge("syncx_test_gauge", "A test gauge", 1.5, "project", `say "hi"\now`, "group", "A")
+	if err := WriteMetrics(path, metrics); err != nil {
+		t.Fatalf("WriteMetrics: %v", err)
+	}
+
+	data, _ := os.ReadFile(path)
+	want := "# HELP syncx_test_gauge A test gauge\n" +
+		"# TYPE syncx_test_gauge gauge\n" +
+		`syncx_test_gauge{group="A",project="say \"hi\"\\now"} 1.5` + "\n"
+	if string(data) != want {
+		t.Errorf("metrics file =\n%s\nwant\n%s", data, want)
+	}
+}
@@ -0,0 +1,375 @@
+package internal
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/smtp"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// Notification sink types
+const (
+	NotifyWebhook = "webhook"
+	NotifySlack   = "slack"
+	NotifyEmail   = "email"
+)
+
+// Notification triggers
+const (
+	TriggerOnFailure = "on_failure"
+	TriggerOnChange  = "on_change"
+	TriggerAlways    = "always"
+)
+
+const defaultNotifyTimeout = 10 * time.Second
+
+// DefaultNotifyTemplate is the message used when a sink has no template
+const DefaultNotifyTemplate = `syncx {{.Command}} {{.Status}} on {{.Host}}: {{.Summary.SuccessCount}} succeeded, {{.Summary.FailureCount}} failed, {{.Summary.EmptyCount}} empty ({{.Summary.TotalProjects}} projects in {{.Duration}})
+{{- range .Failed}}
+- {{.Project.Group}}/{{.Project.Name}}: {{.Message}}
+{{- end}}
+{{- if .Recovered}}
+Recovered: {{join .Recovered ", "}}
+{{- end}}`
+
+// DefaultNotifySubject is the email subject used when a sink has no subject
+const DefaultNotifySubject = `[syncx] {{.Command}} {{.Status}}: {{.Summary.FailureCount}} failed of {{.Summary.TotalProjects}}`
+
+// NotificationConfig is one notification sink in the config file
+type NotificationConfig struct {
+	Name     string   `yaml:"name"`
+	Type     string   `yaml:"type"`     // webhook, slack or email
+	Trigger  string   `yaml:"trigger"`  // on_failure (default), on_change or always
+	Commands []string `yaml:"commands"` // Commands that notify (default: all clone and pull runs)
+	Template string   `yaml:"template"` // Go text/template rendered with a NotificationEvent
+	Timeout  string   `yaml:"timeout"`  // e.g. "10s"
+
+	// webhook and slack
+	URL     string            `yaml:"url"`
+	Headers map[string]string `yaml:"headers"`
+
+	// email
+	SMTPHost    string   `yaml:"smtp_host"`
+	SMTPPort    int      `yaml:"smtp_port"` // default 25
+	Username    string   `yaml:"username"`
+	Password    string   `yaml:"password"`
+	PasswordEnv string   `yaml:"password_env"` // Environment variable holding the password
+	From        string   `yaml:"from"`
+	To          []string `yaml:"to"`
+	Subject     string   `yaml:"subject"` // Go text/template, like template
+}
+
+// NotificationEvent is the data available to notification templates
+type NotificationEvent struct {
+	Command         string            `json:"command"`
+	Status          string            `json:"status"` // "succeeded" or "failed"
+	Host            string            `json:"host"`
+	OutputDirectory string            `json:"output_directory"`
+	InventoryFile   string            `json:"inventory_file"`
+	StartedAt       string            `json:"started_at"`
+	FinishedAt      string            `json:"finished_at"`
+	Duration        string            `json:"duration"`
+	Summary         Summary           `json:"summary"`
+	Failed          []OperationResult `json:"failed"`
+	Changed         bool              `json:"changed"`      // New clones, new failures or recoveries since the previous run
+	NewFailures     []string          `json:"new_failures"` // group/name of projects that started failing in this run
+	Recovered       []string          `json:"recovered"`    // group/name of projects that failed in the previous run
+}
+
+// webhookPayload is the JSON body of generic webhooks
+type webhookPayload struct {
+	Text  string            `json:"text"`
+	Event NotificationEvent `json:"event"`
+}
+
+var notifyFuncs = template.FuncMap{"join": strings.Join}
+
+// NewNotificationEvent describes a finished run, compared with the previous run when known
+func NewNotificationEvent(command, outputDir, inventoryFile string, startedAt time.Time, summary Summary, previous *LastRun) NotificationEvent {
+	host, _ := os.Hostname()
+	event := NotificationEvent{
+		Command:         command,
+		Status:          "succeeded",
+		Host:            host,
+		OutputDirectory: outputDir,
+		InventoryFile:   inventoryFile,
+		StartedAt:       startedAt.Format(time.RFC3339),
+		FinishedAt:      time.Now().Format(time.RFC3339),
+		Duration:        summary.TotalDuration,
+		Summary:         summary,
+	}
+	if event.Duration == "" {
+		event.Duration = time.Since(startedAt).Round(time.Millisecond).String()
+	}
+	if summary.FailureCount > 0 {
+		event.Status = "failed"
+	}
+
+	failing := make(map[string]bool)
+	for _, result := range summary.Results {
+		if !result.Success && !result.IsEmpty {
+			event.Failed = append(event.Failed, result)
+			failing[result.Project.Group+"/"+result.Project.Name] = true
+		}
+	}
+
+	previouslyFailing := make(map[string]bool)
+	if previous != nil {
+		for _, result := range previous.Summary.Results {
+			if !result.Success && !result.IsEmpty {
+				previouslyFailing[result.Project.Group+"/"+result.Project.Name] = true
+			}
+		}
+	}
+
+	// Only projects processed in this run can have recovered
+	processed := make(map[string]bool)
+	for _, result := range summary.Results {
+		processed[result.Project.Group+"/"+result.Project.Name] = true
+	}
+	for key := range failing {
+		if !previouslyFailing[key] {
+			event.NewFailures = append(event.NewFailures, key)
+		}
+	}
+	for key := range previouslyFailing {
+		if processed[key] && !failing[key] {
+			event.Recovered = append(event.Recovered, key)
+		}
+	}
+	sort.Strings(event.NewFailures)
+	sort.Strings(event.Recovered)
+
+	// A pull only changes something when it moved HEAD
+	headMoved := false
+	for _, result := range summary.Results {
+		if result.BeforeCommit != "" && result.AfterCommit != "" && result.BeforeCommit != result.AfterCommit {
+			headMoved = true
+			break
+		}
+	}
+
+	event.Changed = summary.ClonedCount > 0 || headMoved || len(event.NewFailures) > 0 || len(event.Recovered) > 0
+	return event
+}
+
+// ValidateNotification checks a sink from the config file
+func ValidateNotification(n NotificationConfig) error {
+	switch n.Type {
+	case NotifyWebhook, NotifySlack:
+		if n.URL == "" {
+			return fmt.Errorf("%s notification %s needs a url", n.Type, n.DisplayName())
+		}
+	case NotifyEmail:
+		if n.SMTPHost == "" || n.From == "" || len(n.To) == 0 {
+			return fmt.Errorf("email notification %s needs smtp_host, from and to", n.DisplayName())
+		}
+	default:
+		return fmt.Errorf("invalid notification type: %s. Must be 'webhook', 'slack' or 'email'", n.Type)
+	}
+
+	switch n.Trigger {
+	case "", TriggerOnFailure, TriggerOnChange, TriggerAlways:
+	default:
+		return fmt.Errorf("invalid notification trigger: %s. Must be 'on_failure', 'on_change' or 'always'", n.Trigger)
+	}
+
+	if n.Timeout != "" {
+		if _, err := time.ParseDuration(n.Timeout); err != nil {
+			return fmt.Errorf("invalid timeout for notification %s: %w", n.DisplayName(), err)
+		}
+	}
+	if _, err := template.New("message").Funcs(notifyFuncs).Parse(n.Template); err != nil {
+		return fmt.Errorf("invalid template for notification %s: %w", n.DisplayName(), err)
+	}
+	if _, err := template.New("subject").Funcs(notifyFuncs).Parse(n.Subject); err != nil {
+		return fmt.Errorf("invalid subject for notification %s: %w", n.DisplayName(), err)
+	}
+	return nil
+}
+
+// ShouldNotify reports whether the sink fires for the command and event
+func (n NotificationConfig) ShouldNotify(event NotificationEvent) bool {
+	if len(n.Commands) > 0 {
+		matched := false
+		for _, command := range n.Commands {
+			if command == event.Command {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	switch n.Trigger {
+	case TriggerAlways:
+		return true
+	case TriggerOnChange:
+		return event.Changed
+	default:
+		return event.Summary.FailureCount > 0
+	}
+}
+
+// SendNotification renders the message and delivers it to the sink
+func SendNotification(n NotificationConfig, event NotificationEvent) error {
+	message, err := renderNotifyTemplate(n.Template, DefaultNotifyTemplate, event)
+	if err != nil {
+		return fmt.Errorf("failed to render notification %s: %w", n.DisplayName(), err)
+	}
+
+	timeout := defaultNotifyTimeout
+	if n.Timeout != "" {
+		timeout, _ = time.ParseDuration(n.Timeout)
+	}
+
+	switch n.Type {
+	case NotifySlack:
+		err = postJSON(n, map[string]string{"text": message}, timeout)
+	case NotifyWebhook:
+		err = postJSON(n, webhookPayload{Text: message, Event: event}, timeout)
+	case NotifyEmail:
+		var subject string
+		subject, err = renderNotifyTemplate(n.Subject, DefaultNotifySubject, event)
+		if err == nil {
+			err = sendEmail(n, subject, message, timeout)
+		}
+	default:
+		err = fmt.Errorf("unknown notification type: %s", n.Type)
+	}
+
+	if err != nil {
+		FileLog().Warn("notification failed", "notification", n.DisplayName(), "type", n.Type, "error", err.Error())
+		return fmt.Errorf("notification %s failed: %w", n.DisplayName(), err)
+	}
+	FileLog().Info("notification sent", "notification", n.DisplayName(), "type", n.Type)
+	return nil
+}
+
+// DisplayName identifies the sink in messages: its name, recipients or type
+func (n NotificationConfig) DisplayName() string {
+	if n.Name != "" {
+		return n.Name
+	}
+	if n.Type == NotifyEmail && len(n.To) > 0 {
+		return strings.Join(n.To, ",")
+	}
+	return n.Type
+}
+
+func renderNotifyTemplate(text, fallback string, event NotificationEvent) (string, error) {
+	if text == "" {
+		text = fallback
+	}
+	tmpl, err := template.New("notification").Funcs(notifyFuncs).Parse(text)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, event); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(b.String()), nil
+}
+
+// postJSON sends a webhook or Slack incoming-webhook request
+func postJSON(n NotificationConfig, payload interface{}, timeout time.Duration) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, n.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "syncx")
+	for key, value := range n.Headers {
+		req.Header.Set(key, os.ExpandEnv(value))
+	}
+
+	client := &http.Client{Timeout: timeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%s returned %s", n.URL, resp.Status)
+	}
+	return nil
+}
+
+// sendEmail delivers a plain-text message, using STARTTLS and authentication when available
+func sendEmail(n NotificationConfig, subject, body string, timeout time.Duration) error {
+	port := n.SMTPPort
+	if port == 0 {
+		port = 25
+	}
+	addr := net.JoinHostPort(n.SMTPHost, strconv.Itoa(port))
+
+	conn, err := net.DialTimeout("tcp", addr, timeout)
+	if err != nil {
+		return err
+	}
+	conn.SetDeadline(time.Now().Add(timeout))
+
+	client, err := smtp.NewClient(conn, n.SMTPHost)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: n.SMTPHost}); err != nil {
+			return err
+		}
+	}
+
+	if n.Username != "" {
+		password := n.Password
+		if n.PasswordEnv != "" {
+			password = os.Getenv(n.PasswordEnv)
+		}
+		if err := client.Auth(smtp.PlainAuth("", n.Username, password, n.SMTPHost)); err != nil {
+			return err
+		}
+	}
+
+	if err := client.Mail(n.From); err != nil {
+		return err
+	}
+	for _, to := range n.To {
+		if err := client.Rcpt(to); err != nil {
+			return err
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	message := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\nDate: %s\r\nMIME-Version: 1.0\r\nContent-Type: text/plain; charset=utf-8\r\n\r\n%s\r\n",
+		n.From, strings.Join(n.To, ", "), strings.ReplaceAll(subject, "\n", " "), time.Now().Format(time.RFC1123Z), strings.ReplaceAll(body, "\n", "\r\n"))
+	if _, err := w.Write([]byte(message)); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
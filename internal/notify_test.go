@@ -0,0 +1,365 @@
+package internal
+
+import (
+	"bufio"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testEvent(command string, summary Summary) NotificationEvent {
+	return NewNotificationEvent(command, "/tmp/repos", "inventory.json", time.Now(), summary, nil)
+}
+
+func failedResult(group, name string) OperationResult {
+	return OperationResult{Project: ProjectInfo{Group: group, Name: name}, Message: "Clone failed"}
+}
+
+func succeededResult(group, name string) OperationResult {
+	return OperationResult{Success: true, Project: ProjectInfo{Group: group, Name: name}}
+}
+
+func TestSendNotificationWebhook(t *testing.T) {
+	t.Setenv("SYNCX_TEST_TOKEN", "secret")
+
+	var (
+		method  string
+		headers http.Header
+		payload webhookPayload
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method = r.Method
+		headers = r.Header.Clone()
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("decoding payload: %v", err)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	sink := NotificationConfig{
+		Type:     NotifyWebhook,
+		URL:      server.URL,
+		Template: "{{.Command}} {{.Status}}: {{.Summary.FailureCount}} failed",
+		Headers:  map[string]string{"Authorization": "Bearer ${SYNCX_TEST_TOKEN}"},
+	}
+	event := testEvent("pull", Summary{TotalProjects: 2, FailureCount: 1, Results: []OperationResult{failedResult("Backend", "api")}})
+
+	if err := SendNotification(sink, event); err != nil {
+		t.Fatalf("SendNotification: %v", err)
+	}
+	if method != http.MethodPost {
+		t.Errorf("method = %s, want POST", method)
+	}
+	if got := headers.Get("Content-Type"); got != "application/json" {
+		t.Errorf("Content-Type = %q, want application/json", got)
+	}
+	if got := headers.Get("User-Agent"); got != "syncx" {
+		t.Errorf("User-Agent = %q, want syncx", got)
+	}
+	if got := headers.Get("Authorization"); got != "Bearer secret" {
+		t.Errorf("Authorization = %q, want the expanded environment variable", got)
+	}
+	if payload.Text != "pull failed: 1 failed" {
+		t.Errorf("text = %q", payload.Text)
+	}
+	if payload.Event.Command != "pull" || len(payload.Event.Failed) != 1 || payload.Event.Failed[0].Project.Name != "api" {
+		t.Errorf("event = %+v, want the pull event with the failed project", payload.Event)
+	}
+}
+
+func TestSendNotificationSlack(t *testing.T) {
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decoding payload: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	sink := NotificationConfig{Type: NotifySlack, URL: server.URL, Template: "{{.Command}} {{.Status}}"}
+	if err := SendNotification(sink, testEvent("clone", Summary{TotalProjects: 1, SuccessCount: 1})); err != nil {
+		t.Fatalf("SendNotification: %v", err)
+	}
+
+	want := map[string]interface{}{"text": "clone succeeded"}
+	if !reflect.DeepEqual(body, want) {
+		t.Errorf("body = %v, want %v", body, want)
+	}
+}
+
+func TestSendNotificationNon2xx(t *testing.T) {
+	for _, status := range []int{http.StatusMovedPermanently, http.StatusBadRequest, http.StatusInternalServerError} {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(status)
+		}))
+
+		for _, kind := range []string{NotifyWebhook, NotifySlack} {
+			sink := NotificationConfig{Name: "ops", Type: kind, URL: server.URL}
+			err := SendNotification(sink, testEvent("pull", Summary{}))
+			if err == nil {
+				t.Errorf("%s with HTTP %d: expected an error", kind, status)
+				continue
+			}
+			if !strings.Contains(err.Error(), "notification ops failed") || !strings.Contains(err.Error(), http.StatusText(status)) {
+				t.Errorf("%s with HTTP %d: error = %q", kind, status, err)
+			}
+		}
+		server.Close()
+	}
+}
+
+// fakeMail is what the fake SMTP server received
+type fakeMail struct {
+	auth string
+	from string
+	to   []string
+	data string
+}
+
+// startFakeSMTP accepts one SMTP session on a local port, advertising AUTH but not STARTTLS
+func startFakeSMTP(t *testing.T) (int, <-chan fakeMail) {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	received := make(chan fakeMail, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+		var mail fakeMail
+		reader := bufio.NewReader(conn)
+		reply := func(lines ...string) {
+			io.WriteString(conn, strings.Join(lines, "\r\n")+"\r\n")
+		}
+
+		reply("220 localhost fake SMTP")
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+			line = strings.TrimRight(line, "\r\n")
+			command := strings.ToUpper(line)
+
+			switch {
+			case strings.HasPrefix(command, "EHLO"), strings.HasPrefix(command, "HELO"):
+				reply("250-localhost", "250 AUTH PLAIN")
+			case strings.HasPrefix(command, "AUTH PLAIN "):
+				decoded, _ := base64.StdEncoding.DecodeString(strings.TrimSpace(line[len("AUTH PLAIN "):]))
+				mail.auth = string(decoded)
+				reply("235 2.7.0 Authentication successful")
+			case strings.HasPrefix(command, "MAIL FROM:"):
+				mail.from = strings.Trim(line[len("MAIL FROM:"):], "<> ")
+				reply("250 OK")
+			case strings.HasPrefix(command, "RCPT TO:"):
+				mail.to = append(mail.to, strings.Trim(line[len("RCPT TO:"):], "<> "))
+				reply("250 OK")
+			case command == "DATA":
+				reply("354 End data with <CR><LF>.<CR><LF>")
+				var data strings.Builder
+				for {
+					dataLine, err := reader.ReadString('\n')
+					if err != nil {
+						return
+					}
+					if dataLine == ".\r\n" {
+						break
+					}
+					data.WriteString(dataLine)
+				}
+				mail.data = data.String()
+				reply("250 OK")
+			case command == "QUIT":
+				reply("221 Bye")
+				received <- mail
+				return
+			default:
+				reply("502 Command not implemented")
+			}
+		}
+	}()
+
+	return listener.Addr().(*net.TCPAddr).Port, received
+}
+
+func TestSendNotificationEmail(t *testing.T) {
+	t.Setenv("SYNCX_TEST_SMTP_PASSWORD", "hunter2")
+	port, received := startFakeSMTP(t)
+
+	sink := NotificationConfig{
+		Type:        NotifyEmail,
+		SMTPHost:    "127.0.0.1",
+		SMTPPort:    port,
+		Username:    "syncx",
+		PasswordEnv: "SYNCX_TEST_SMTP_PASSWORD",
+		From:        "syncx@example.com",
+		To:          []string{"ops@example.com", "dev@example.com"},
+		Subject:     "{{.Command}} {{.Status}}",
+		Template:    "line one\nline two",
+	}
+	if err := SendNotification(sink, testEvent("pull", Summary{FailureCount: 1})); err != nil {
+		t.Fatalf("SendNotification: %v", err)
+	}
+
+	var mail fakeMail
+	select {
+	case mail = <-received:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the fake SMTP server received no message")
+	}
+
+	if mail.auth != "\x00syncx\x00hunter2" {
+		t.Errorf("auth = %q, want the username and the password from the environment", mail.auth)
+	}
+	if mail.from != "syncx@example.com" {
+		t.Errorf("from = %q", mail.from)
+	}
+	if !reflect.DeepEqual(mail.to, sink.To) {
+		t.Errorf("recipients = %v, want %v", mail.to, sink.To)
+	}
+	for _, want := range []string{
+		"From: syncx@example.com\r\n",
+		"To: ops@example.com, dev@example.com\r\n",
+		"Subject: pull failed\r\n",
+		"Content-Type: text/plain; charset=utf-8\r\n",
+		"\r\n\r\nline one\r\nline two\r\n",
+	} {
+		if !strings.Contains(mail.data, want) {
+			t.Errorf("message is missing %q:\n%s", want, mail.data)
+		}
+	}
+}
+
+func TestShouldNotify(t *testing.T) {
+	failed := NotificationEvent{Command: "pull", Summary: Summary{FailureCount: 1}}
+	changed := NotificationEvent{Command: "pull", Changed: true}
+	quiet := NotificationEvent{Command: "pull"}
+
+	tests := []struct {
+		name  string
+		sink  NotificationConfig
+		event NotificationEvent
+		want  bool
+	}{
+		{"default trigger fires on failure", NotificationConfig{}, failed, true},
+		{"default trigger ignores a clean run", NotificationConfig{}, changed, false},
+		{"on_failure fires on failure", NotificationConfig{Trigger: TriggerOnFailure}, failed, true},
+		{"on_failure ignores a change", NotificationConfig{Trigger: TriggerOnFailure}, changed, false},
+		{"on_change fires on a change", NotificationConfig{Trigger: TriggerOnChange}, changed, true},
+		{"on_change ignores an unchanged run", NotificationConfig{Trigger: TriggerOnChange}, quiet, false},
+		{"always fires on a quiet run", NotificationConfig{Trigger: TriggerAlways}, quiet, true},
+		{"listed command fires", NotificationConfig{Trigger: TriggerAlways, Commands: []string{"clone", "pull"}}, quiet, true},
+		{"unlisted command is skipped", NotificationConfig{Trigger: TriggerAlways, Commands: []string{"clone"}}, quiet, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.sink.ShouldNotify(tt.event); got != tt.want {
+				t.Errorf("ShouldNotify() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewNotificationEventChanges(t *testing.T) {
+	previous := &LastRun{Summary: Summary{Results: []OperationResult{
+		failedResult("Backend", "api"),
+		failedResult("Backend", "auth"),
+		failedResult("Frontend", "app"),
+		succeededResult("Frontend", "web"),
+	}}}
+
+	tests := []struct {
+		name        string
+		summary     Summary
+		previous    *LastRun
+		changed     bool
+		newFailures []string
+		recovered   []string
+	}{
+		{
+			name:    "first run without failures",
+			summary: Summary{Results: []OperationResult{succeededResult("Backend", "api")}},
+		},
+		{
+			name:        "first run with a failure",
+			summary:     Summary{FailureCount: 1, Results: []OperationResult{failedResult("Backend", "api")}},
+			changed:     true,
+			newFailures: []string{"Backend/api"},
+		},
+		{
+			name:    "new clone",
+			summary: Summary{ClonedCount: 1, Results: []OperationResult{succeededResult("Backend", "api")}},
+			changed: true,
+		},
+		{
+			name: "pull that moved HEAD",
+			summary: Summary{Results: []OperationResult{
+				{Success: true, Project: ProjectInfo{Group: "Backend", Name: "api"}, BeforeCommit: "aaa", AfterCommit: "bbb"},
+			}},
+			changed: true,
+		},
+		{
+			name: "pull that left HEAD alone",
+			summary: Summary{Results: []OperationResult{
+				{Success: true, Project: ProjectInfo{Group: "Backend", Name: "api"}, BeforeCommit: "aaa", AfterCommit: "aaa"},
+			}},
+		},
+		{
+			name: "same failures as the previous run",
+			summary: Summary{FailureCount: 2, Results: []OperationResult{
+				failedResult("Backend", "api"),
+				failedResult("Backend", "auth"),
+			}},
+			previous: previous,
+		},
+		{
+			name: "new failure and recoveries",
+			summary: Summary{FailureCount: 1, Results: []OperationResult{
+				succeededResult("Backend", "auth"),
+				succeededResult("Backend", "api"),
+				failedResult("Frontend", "web"),
+			}},
+			previous:    previous,
+			changed:     true,
+			newFailures: []string{"Frontend/web"},
+			recovered:   []string{"Backend/api", "Backend/auth"},
+		},
+		{
+			name:     "empty repositories are not failures",
+			summary:  Summary{Results: []OperationResult{{Project: ProjectInfo{Group: "Backend", Name: "empty"}, IsEmpty: true}}},
+			previous: previous,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := NewNotificationEvent("pull", "/tmp/repos", "inventory.json", time.Now(), tt.summary, tt.previous)
+			if event.Changed != tt.changed {
+				t.Errorf("Changed = %v, want %v", event.Changed, tt.changed)
+			}
+			if !reflect.DeepEqual(event.NewFailures, tt.newFailures) {
+				t.Errorf("NewFailures = %v, want %v", event.NewFailures, tt.newFailures)
+			}
+			if !reflect.DeepEqual(event.Recovered, tt.recovered) {
+				t.Errorf("Recovered = %v, want %v", event.Recovered, tt.recovered)
+			}
+		})
+	}
+}
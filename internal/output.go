@@ -0,0 +1,155 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// Output formats
+const (
+	OutputText   = "text"
+	OutputJSON   = "json"
+	OutputNDJSON = "ndjson"
+)
+
+// Event types emitted in structured output
+const (
+	EventResult  = "result"
+	EventSummary = "summary"
+	EventError   = "error"
+)
+
+// OutputEvent is a single NDJSON line
+type OutputEvent struct {
+	Event     string      `json:"event"`
+	Command   string      `json:"command"`
+	Timestamp string      `json:"timestamp"`
+	Data      interface{} `json:"data,omitempty"`
+	Message   string      `json:"message,omitempty"`
+}
+
+// OutputDocument is the single object written in JSON mode
+type OutputDocument struct {
+	Command string        `json:"command"`
+	Results []interface{} `json:"results"`
+	Summary interface{}   `json:"summary,omitempty"`
+	Errors  []string      `json:"errors,omitempty"`
+}
+
+// Emitter writes machine-readable results for scripts and dashboards
+// In text mode every method is a no-op
+type Emitter struct {
+	format  string
+	command string
+	writer  io.Writer
+	mu      sync.Mutex
+	doc     OutputDocument
+}
+
+// ValidateOutputFormat checks an --output-format value
+func ValidateOutputFormat(format string) error {
+	switch format {
+	case OutputText, OutputJSON, OutputNDJSON:
+		return nil
+	}
+	return fmt.Errorf("invalid output format: %s. Must be 'text', 'json' or 'ndjson'", format)
+}
+
+// NewEmitter creates an emitter for the given command
+func NewEmitter(format, command string, writer io.Writer) *Emitter {
+	return &Emitter{
+		format:  format,
+		command: command,
+		writer:  writer,
+		doc: OutputDocument{
+			Command: command,
+			Results: []interface{}{},
+		},
+	}
+}
+
+// Structured reports whether JSON or NDJSON output is active
+func (e *Emitter) Structured() bool {
+	return e != nil && e.format != OutputText
+}
+
+// Result emits one per-repository result; NDJSON streams it immediately
+// Safe to call from parallel workers
+func (e *Emitter) Result(result interface{}) {
+	if !e.Structured() {
+		return
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.format == OutputNDJSON {
+		e.writeEvent(OutputEvent{Event: EventResult, Data: result})
+		return
+	}
+	e.doc.Results = append(e.doc.Results, result)
+}
+
+// Summary emits the run-level summary
+func (e *Emitter) Summary(summary interface{}) {
+	if !e.Structured() {
+		return
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.format == OutputNDJSON {
+		e.writeEvent(OutputEvent{Event: EventSummary, Data: summary})
+		return
+	}
+	e.doc.Summary = summary
+}
+
+// Error emits an error that stopped or degraded the command
+func (e *Emitter) Error(format string, args ...interface{}) {
+	if !e.Structured() {
+		return
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	message := fmt.Sprintf(format, args...)
+	if e.format == OutputNDJSON {
+		e.writeEvent(OutputEvent{Event: EventError, Message: message})
+		return
+	}
+	e.doc.Errors = append(e.doc.Errors, message)
+}
+
+// Flush writes the JSON document; NDJSON has already been streamed
+func (e *Emitter) Flush() {
+	if e == nil || e.format != OutputJSON {
+		return
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	data, err := json.MarshalIndent(e.doc, "", "  ")
+	if err != nil {
+		return
+	}
+	fmt.Fprintln(e.writer, string(data))
+}
+
+// writeEvent writes one NDJSON line; the caller must hold e.mu
+func (e *Emitter) writeEvent(event OutputEvent) {
+	event.Command = e.command
+	event.Timestamp = time.Now().Format(time.RFC3339)
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+	fmt.Fprintln(e.writer, string(data))
+}
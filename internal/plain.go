@@ -0,0 +1,134 @@
+package internal
+
+import (
+	"io"
+	"os"
+	"strings"
+	"unicode"
+
+	"github.com/fatih/color"
+	"github.com/mattn/go-isatty"
+)
+
+// plainOutput disables colors, emoji, the banner, spinners and redrawing progress bars
+var plainOutput bool
+
+// statusMarkers replace the emoji that carry meaning; other emoji are dropped
+var statusMarkers = map[rune]string{
+	'✅': "[OK]",
+	'❌': "[FAIL]",
+	'⚠': "[WARN]",
+	'ℹ': "[INFO]",
+	'🐛': "[DEBUG]",
+	'⏭': "[SKIP]",
+	'💡': "[TIP]",
+	'📥': "[CLONE]",
+	'📤': "[PULL]",
+	'🔄': "[UPDATE]",
+	'🔁': "[RETRY]",
+	'📭': "[EMPTY]",
+	'📝': "[DIRTY]",
+	'⬇': "[BEHIND]",
+	'⬆': "[AHEAD]",
+	'➕': "[+]",
+	'➖': "[-]",
+	'❓': "[?]",
+	'📦': "[STASH]",
+	'🌿': "[BRANCH]",
+	'🔀': "[IN PROGRESS]",
+	'📌': "[DETACHED]",
+	'🔗': "[REMOTE]",
+}
+
+// asciiReplacements keep the layout readable without Unicode
+var asciiReplacements = map[rune]string{
+	'═': "=",
+	'─': "-",
+	'•': "*",
+	'→': "->",
+	'…': "...",
+	'±': "+/-",
+	'█': "#",
+	'░': ".",
+}
+
+// DetectPlainOutput reports whether plain output should be used for the given stream:
+// requested with --plain or --no-color, NO_COLOR is set, or the stream is not a terminal
+func DetectPlainOutput(out *os.File, plain, noColor bool) bool {
+	if plain || noColor || os.Getenv("NO_COLOR") != "" {
+		return true
+	}
+	return !isatty.IsTerminal(out.Fd()) && !isatty.IsCygwinTerminal(out.Fd())
+}
+
+// SetPlainOutput switches all console output to plain, line-oriented ASCII
+func SetPlainOutput(plain bool) {
+	plainOutput = plain
+	if plain {
+		color.NoColor = true
+		color.Output = &asciiWriter{out: color.Output}
+	}
+}
+
+// PlainOutput reports whether plain output is active
+func PlainOutput() bool {
+	return plainOutput
+}
+
+// asciiWriter rewrites emoji into ASCII status markers
+type asciiWriter struct {
+	out io.Writer
+}
+
+func (w *asciiWriter) Write(p []byte) (int, error) {
+	if _, err := io.WriteString(w.out, toASCII(string(p))); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// toASCII replaces status emoji with markers and drops decorative symbols
+func toASCII(text string) string {
+	var b strings.Builder
+	runes := []rune(text)
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		if r <= unicode.MaxASCII {
+			b.WriteRune(r)
+			continue
+		}
+		if replacement, ok := asciiReplacements[r]; ok {
+			b.WriteString(replacement)
+			continue
+		}
+		if r == '\uFE0F' || r == '\u200D' {
+			continue
+		}
+
+		marker, isMarker := statusMarkers[r]
+		if !isMarker && !unicode.Is(unicode.So, r) {
+			// Letters in project names and messages are kept
+			b.WriteRune(r)
+			continue
+		}
+
+		// Skip the variation selector and collapse the padding that followed the emoji
+		for i+1 < len(runes) && runes[i+1] == '\uFE0F' {
+			i++
+		}
+		spaces := 0
+		for i+1 < len(runes) && runes[i+1] == ' ' {
+			spaces++
+			i++
+		}
+		if isMarker {
+			b.WriteString(marker)
+			if spaces > 0 {
+				b.WriteByte(' ')
+			}
+		}
+	}
+
+	return b.String()
+}
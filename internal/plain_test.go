@@ -0,0 +1,31 @@
+package internal
+
+import "testing"
+
+func TestToASCII(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"plain text is unchanged", "Cloned: api (3 projects)\n", "Cloned: api (3 projects)\n"},
+		{"status emoji becomes a marker", "✅ Cloned: api", "[OK] Cloned: api"},
+		{"emoji padding collapses to one space", "❌   Failed: api", "[FAIL] Failed: api"},
+		{"variation selector is dropped", "⚠️  Warning", "[WARN] Warning"},
+		{"marker without padding", "⬇3 ⬆1", "[BEHIND]3 [AHEAD]1"},
+		{"decorative emoji is dropped with its padding", "🚀 Starting clone", "Starting clone"},
+		{"zero width joiner is dropped", "👩‍💻 dev", "dev"},
+		{"box drawing becomes ASCII", "═══ Summary ───", "=== Summary ---"},
+		{"arrows and ellipsis", "a → b…", "a -> b..."},
+		{"letters in names are kept", "✅ Cloned: café-ünïcode", "[OK] Cloned: café-ünïcode"},
+		{"progress bar", "[███░░]", "[###..]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := toASCII(tt.text); got != tt.want {
+				t.Errorf("toASCII(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/schollz/progressbar/v3"
+)
+
+// plainProgressInterval is how often plain mode reports progress
+const plainProgressInterval = 5 * time.Second
+
+// ProgressBar redraws a bar on a terminal; in plain mode it prints "N/M done" lines instead
+type ProgressBar struct {
+	bar *progressbar.ProgressBar
+
+	mu          sync.Mutex
+	description string
+	total       int
+	done        int
+	lastReport  time.Time
+}
+
+// NewProgressBar creates a progress bar for total items
+func NewProgressBar(total int, description string, options ...progressbar.Option) *ProgressBar {
+	if plainOutput {
+		return &ProgressBar{
+			description: description,
+			total:       total,
+			lastReport:  time.Now(),
+		}
+	}
+
+	options = append([]progressbar.Option{progressbar.OptionSetDescription(description)}, options...)
+	return &ProgressBar{bar: progressbar.NewOptions(total, options...)}
+}
+
+// Add advances the progress by n items
+func (p *ProgressBar) Add(n int) error {
+	if p.bar != nil {
+		return p.bar.Add(n)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.done += n
+	if p.done >= p.total || time.Since(p.lastReport) >= plainProgressInterval {
+		p.report()
+	}
+	return nil
+}
+
+// Describe changes the description shown next to the bar (ignored in plain mode)
+func (p *ProgressBar) Describe(description string) {
+	if p.bar != nil {
+		p.bar.Describe(description)
+	}
+}
+
+// Finish completes the bar; plain mode reports the final count if it was not printed yet
+func (p *ProgressBar) Finish() error {
+	if p.bar != nil {
+		return p.bar.Finish()
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.done < p.total {
+		p.report()
+	}
+	return nil
+}
+
+// report prints one progress line; the caller must hold p.mu
+func (p *ProgressBar) report() {
+	fmt.Fprintf(color.Output, "%s: %d/%d done\n", strings.TrimSuffix(p.description, "..."), p.done, p.total)
+	p.lastReport = time.Now()
+}
@@ -0,0 +1,163 @@
+package internal
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ArchiveDirName is the folder of the output directory that pruned repositories are moved to
+const ArchiveDirName = "archive"
+
+// Reasons a repository is reported as orphaned
+const (
+	OrphanRemovedFromInventory = "removed-from-inventory"
+	OrphanNotInInventory       = "not-in-inventory"
+)
+
+// FindOrphanRepositories lists clones that no inventory project accounts for: tracked
+// projects that were removed from the inventory and repositories on disk whose origin
+// matches no project. projects must carry the paths they are expected at.
+// A repository that contains the path of an inventory project is never an orphan.
+func FindOrphanRepositories(tracker *ProjectTracker, projects []ProjectInfo, repoPaths []string) []OrphanRepository {
+	inventoryURLs := make(map[string]bool)
+	inventoryPaths := make(map[string]bool)
+	for _, project := range projects {
+		inventoryURLs[NormalizeRemoteURL(project.URL)] = true
+		if project.LocalPath != "" {
+			inventoryPaths[filepath.Clean(project.LocalPath)] = true
+		}
+	}
+
+	var orphans []OrphanRepository
+	seen := make(map[string]bool)
+
+	if tracker != nil {
+		matched := make(map[int]bool)
+		for _, index := range MatchTrackedProjects(tracker, projects) {
+			matched[index] = true
+		}
+		for i, tracked := range tracker.Projects {
+			path := filepath.Clean(tracked.LocalPath)
+			if matched[i] || inventoryURLs[NormalizeRemoteURL(tracked.URL)] ||
+				inventoryPaths[path] || seen[path] || containsAnyPath(path, inventoryPaths) ||
+				!IsGitRepository(path) {
+				continue
+			}
+			seen[path] = true
+			orphans = append(orphans, OrphanRepository{
+				Path:      path,
+				Name:      tracked.Name,
+				Group:     tracked.Group,
+				RemoteURL: tracked.URL,
+				Reason:    OrphanRemovedFromInventory,
+			})
+		}
+	}
+
+	for _, repoPath := range repoPaths {
+		path := filepath.Clean(repoPath)
+		if seen[path] || inventoryPaths[path] || containsAnyPath(path, inventoryPaths) {
+			continue
+		}
+		remote, _ := GetRemoteURL(path)
+		if remote != "" && inventoryURLs[NormalizeRemoteURL(remote)] {
+			continue
+		}
+		seen[path] = true
+		orphans = append(orphans, OrphanRepository{
+			Path:      path,
+			Name:      filepath.Base(path),
+			RemoteURL: remote,
+			Reason:    OrphanNotInInventory,
+		})
+	}
+
+	sort.Slice(orphans, func(i, j int) bool { return orphans[i].Path < orphans[j].Path })
+	return orphans
+}
+
+// containsAnyPath reports whether one of paths lies below dir
+func containsAnyPath(dir string, paths map[string]bool) bool {
+	for path := range paths {
+		if IsBelowDirectory(path, dir) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsBelowDirectory reports whether path lies strictly inside dir (dir itself is not inside)
+func IsBelowDirectory(path, dir string) bool {
+	rel, err := filepath.Rel(filepath.Clean(dir), filepath.Clean(path))
+	if err != nil || rel == "." {
+		return false
+	}
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// ArchiveRepository moves a repository into archiveDir, keeping its path relative to
+// baseDir. An existing archive entry is never overwritten: a timestamp is appended instead.
+func ArchiveRepository(path, baseDir, archiveDir string) (string, error) {
+	// Never touch anything outside the output directory, nor the directory itself
+	if !IsBelowDirectory(path, baseDir) {
+		return "", fmt.Errorf("refusing to archive %s: not inside %s", path, baseDir)
+	}
+	rel, _ := filepath.Rel(baseDir, path)
+
+	destination := filepath.Join(archiveDir, rel)
+	if _, err := os.Stat(destination); err == nil {
+		destination = fmt.Sprintf("%s-%s", destination, time.Now().Format("20060102-150405"))
+	}
+	if err := os.MkdirAll(filepath.Dir(destination), 0755); err != nil {
+		return "", fmt.Errorf("failed to create %s: %w", filepath.Dir(destination), err)
+	}
+	if err := os.Rename(path, destination); err != nil {
+		return "", fmt.Errorf("failed to move %s to %s: %w", path, destination, err)
+	}
+
+	RemoveEmptyParents(path, baseDir)
+	return destination, nil
+}
+
+// DeleteRepository removes a repository and the directories it leaves empty
+func DeleteRepository(path, baseDir string) error {
+	// Never touch anything outside the output directory, nor the directory itself
+	if !IsBelowDirectory(path, baseDir) {
+		return fmt.Errorf("refusing to delete %s: not inside %s", path, baseDir)
+	}
+	if err := os.RemoveAll(path); err != nil {
+		return fmt.Errorf("failed to delete %s: %w", path, err)
+	}
+	RemoveEmptyParents(path, baseDir)
+	return nil
+}
+
+// RemoveEmptyParents deletes the empty directories above path, stopping at stopDir
+func RemoveEmptyParents(path, stopDir string) {
+	stopDir = filepath.Clean(stopDir)
+	for dir := filepath.Dir(filepath.Clean(path)); strings.HasPrefix(dir, stopDir+string(filepath.Separator)); dir = filepath.Dir(dir) {
+		entries, err := os.ReadDir(dir)
+		if err != nil || len(entries) > 0 {
+			return
+		}
+		if err := os.Remove(dir); err != nil {
+			return
+		}
+	}
+}
+
+// RemoveTrackedPath drops the tracker entries of the repository at path
+func RemoveTrackedPath(tracker *ProjectTracker, path string) {
+	path = filepath.Clean(path)
+	kept := []TrackedProject{}
+	for _, tracked := range tracker.Projects {
+		if filepath.Clean(tracked.LocalPath) != path {
+			kept = append(kept, tracked)
+		}
+	}
+	tracker.Projects = kept
+}
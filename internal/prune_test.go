@@ -0,0 +1,94 @@
+package internal
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// initTestRepository creates a git repository at path with the given origin (none when empty)
+func initTestRepository(t *testing.T, path, origin string) string {
+	t.Helper()
+	if err := os.MkdirAll(path, 0755); err != nil {
+		t.Fatal(err)
+	}
+	commands := [][]string{{"init", "--quiet", path}}
+	if origin != "" {
+		commands = append(commands, []string{"-C", path, "remote", "add", "origin", origin})
+	}
+	for _, args := range commands {
+		if output, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, output)
+		}
+	}
+	return path
+}
+
+func TestFindOrphanRepositories(t *testing.T) {
+	base := t.TempDir()
+	repoPath := func(name string) string { return filepath.Join(base, "projects", "olive", name) }
+
+	kept := initTestRepository(t, repoPath("kept"), "git@gitlab.com:olive/kept.git")
+	removed := initTestRepository(t, repoPath("removed"), "git@gitlab.com:olive/removed.git")
+	moved := initTestRepository(t, repoPath("old-place"), "git@gitlab.com:olive/moved.git")
+	stray := initTestRepository(t, repoPath("stray"), "https://gitlab.com/olive/stray.git")
+	local := initTestRepository(t, repoPath("local"), "")
+	parent := initTestRepository(t, filepath.Join(base, "projects", "umbrella"), "git@gitlab.com:olive/umbrella.git")
+	initTestRepository(t, filepath.Join(parent, "nested"), "git@gitlab.com:olive/nested.git")
+
+	projects := []ProjectInfo{
+		{Name: "kept", URL: "gitlab.com:olive/kept.git", LocalPath: kept},
+		{Name: "moved", URL: "gitlab.com:olive/moved.git", LocalPath: repoPath("new-place")},
+		{Name: "nested", URL: "gitlab.com:olive/nested.git", LocalPath: filepath.Join(parent, "nested")},
+	}
+	tracker := &ProjectTracker{Projects: []TrackedProject{
+		{Name: "kept", Group: "Olive", URL: "git@gitlab.com:olive/kept.git", LocalPath: kept},
+		{Name: "removed", Group: "Olive", URL: "gitlab.com:olive/removed.git", LocalPath: removed},
+		{Name: "deleted", Group: "Olive", URL: "gitlab.com:olive/deleted.git", LocalPath: repoPath("deleted")},
+	}}
+	onDisk := []string{kept, removed, moved, stray, local, parent}
+
+	got := FindOrphanRepositories(tracker, projects, onDisk)
+	want := []OrphanRepository{
+		{Path: local, Name: "local", Reason: OrphanNotInInventory},
+		{Path: removed, Name: "removed", Group: "Olive", RemoteURL: "gitlab.com:olive/removed.git", Reason: OrphanRemovedFromInventory},
+		{Path: stray, Name: "stray", RemoteURL: "https://gitlab.com/olive/stray.git", Reason: OrphanNotInInventory},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FindOrphanRepositories() =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestFindOrphanRepositoriesWithoutTracker(t *testing.T) {
+	base := t.TempDir()
+	stray := initTestRepository(t, filepath.Join(base, "stray"), "git@gitlab.com:olive/stray.git")
+
+	got := FindOrphanRepositories(nil, nil, []string{stray})
+	if len(got) != 1 || got[0].Path != stray || got[0].Reason != OrphanNotInInventory {
+		t.Errorf("FindOrphanRepositories() = %+v", got)
+	}
+}
+
+func TestIsBelowDirectory(t *testing.T) {
+	tests := []struct {
+		path string
+		dir  string
+		want bool
+	}{
+		{"/repos/projects/api", "/repos", true},
+		{"/repos/projects/api/", "/repos/projects", true},
+		{"/repos", "/repos", false},
+		{"/repos/../etc", "/repos", false},
+		{"/repos-other/api", "/repos", false},
+		{"/elsewhere", "/repos", false},
+		{"/repos/..hidden", "/repos", true},
+	}
+
+	for _, tt := range tests {
+		if got := IsBelowDirectory(tt.path, tt.dir); got != tt.want {
+			t.Errorf("IsBelowDirectory(%q, %q) = %v, want %v", tt.path, tt.dir, got, tt.want)
+		}
+	}
+}
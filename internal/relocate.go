@@ -0,0 +1,124 @@
+package internal
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Outcomes of a relocation
+const (
+	RelocationMoved        = "moved"
+	RelocationWouldMove    = "would-move"
+	RelocationAlreadyMoved = "already-moved"
+	RelocationCollision    = "collision"
+	RelocationFailed       = "failed"
+)
+
+// RelocateMovedProjects moves the clones of projects whose computed path no longer matches
+// the path in the tracker (after a group rename or a change of the path rules) and points
+// the tracker at the new location. Nothing at the destination is ever overwritten: on a
+// collision the clone stays where it is and keeps being used from there.
+func RelocateMovedProjects(projects []ProjectInfo, baseDir, inventoryFile string, dryRun bool) ([]Relocation, error) {
+	tracker, err := LoadOrCreateTracker(baseDir, inventoryFile)
+	if err != nil {
+		return nil, err
+	}
+
+	tracked := MatchTrackedProjects(tracker, projects)
+
+	var relocations []Relocation
+	changed := false
+	for _, project := range projects {
+		i, ok := tracked[ProjectIdentity(project)]
+		if !ok {
+			continue
+		}
+		from := filepath.Clean(tracker.Projects[i].LocalPath)
+		to := CreateProjectLocalPath(baseDir, project.URL, project.Group)
+		if to == "" || tracker.Projects[i].LocalPath == "" || from == filepath.Clean(to) {
+			continue
+		}
+		to = filepath.Clean(to)
+		project.LocalPath = to
+
+		relocation := relocateRepository(project, from, to, baseDir, dryRun)
+		if relocation.Status == "" {
+			continue
+		}
+		if !dryRun && (relocation.Status == RelocationMoved || relocation.Status == RelocationAlreadyMoved) {
+			tracker.Projects[i].LocalPath = to
+			tracker.Projects[i].Group = project.Group
+			changed = true
+			FileLog().Info("repository relocated", "project", project.Name, "from", from, "to", to, "status", relocation.Status)
+		}
+		relocations = append(relocations, relocation)
+	}
+
+	if changed {
+		if err := SaveTracker(tracker); err != nil {
+			return relocations, err
+		}
+	}
+	return relocations, nil
+}
+
+// relocateRepository moves one clone; an empty Status means there was nothing to move
+func relocateRepository(project ProjectInfo, from, to, baseDir string, dryRun bool) Relocation {
+	relocation := Relocation{Project: project, From: from, To: to}
+
+	if !IsGitRepository(from) {
+		// The clone was moved by hand, or is gone and will simply be cloned again
+		if IsGitRepository(to) && sameOrigin(to, project.URL) {
+			relocation.Status = RelocationAlreadyMoved
+		}
+		return relocation
+	}
+
+	if entries, err := os.ReadDir(to); err == nil {
+		if len(entries) > 0 {
+			relocation.Status = RelocationCollision
+			if IsGitRepository(to) && sameOrigin(to, project.URL) {
+				relocation.Detail = "another clone of the same repository is already there"
+			} else {
+				relocation.Detail = "destination is not empty"
+			}
+			return relocation
+		}
+	} else if !os.IsNotExist(err) {
+		relocation.Status = RelocationCollision
+		relocation.Detail = "destination is not a directory"
+		return relocation
+	}
+
+	if dryRun {
+		relocation.Status = RelocationWouldMove
+		return relocation
+	}
+
+	// An empty directory left at the destination is replaced
+	os.Remove(to)
+	if err := os.MkdirAll(filepath.Dir(to), 0755); err != nil {
+		relocation.Status = RelocationFailed
+		relocation.Detail = fmt.Sprintf("failed to create %s: %v", filepath.Dir(to), err)
+		return relocation
+	}
+	if err := os.Rename(from, to); err != nil {
+		relocation.Status = RelocationFailed
+		relocation.Detail = err.Error()
+		return relocation
+	}
+
+	RemoveEmptyParents(from, baseDir)
+	relocation.Status = RelocationMoved
+	return relocation
+}
+
+// sameOrigin reports whether the repository at to is a clone of projectURL
+func sameOrigin(to, projectURL string) bool {
+	remote, err := GetRemoteURL(to)
+	if err != nil {
+		return false
+	}
+	return NormalizeRemoteURL(remote) == NormalizeRemoteURL(projectURL)
+}
@@ -0,0 +1,48 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Kinds of remote URL drift
+const (
+	RemoteDriftURL      = "url"      // origin points at another repository location
+	RemoteDriftProtocol = "protocol" // same repository, other protocol than requested
+)
+
+// RemoteProtocol reports whether a remote URL uses "http" or "ssh"
+func RemoteProtocol(remoteURL string) string {
+	lower := strings.ToLower(remoteURL)
+	if strings.HasPrefix(lower, "https://") || strings.HasPrefix(lower, "http://") {
+		return "http"
+	}
+	return "ssh"
+}
+
+// CheckRemoteDrift compares a clone's origin with its inventory project. protocol is the
+// protocol origin should use, or empty to accept the one it uses now. It returns the URL
+// origin should have and the kind of drift, empty when origin is as expected.
+func CheckRemoteDrift(project ProjectInfo, origin, protocol string) (string, string) {
+	if protocol == "" {
+		protocol = RemoteProtocol(origin)
+	}
+	expected := FormatGitURL(project.URL, protocol)
+
+	switch {
+	case NormalizeRemoteURL(origin) != NormalizeRemoteURL(project.URL):
+		return expected, RemoteDriftURL
+	case RemoteProtocol(origin) != protocol:
+		return expected, RemoteDriftProtocol
+	}
+	return expected, ""
+}
+
+// SetRemoteURL points a clone's origin at a new URL
+func SetRemoteURL(localPath, remoteURL string) error {
+	output, err := RunGitCommand("-C", localPath, "remote", "set-url", "origin", remoteURL)
+	if err != nil {
+		return fmt.Errorf("git remote set-url failed%s", gitErrorDetail(output))
+	}
+	return nil
+}
@@ -0,0 +1,274 @@
+package internal
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Report formats, chosen by the file extension
+const (
+	ReportHTML     = "html"
+	ReportMarkdown = "markdown"
+)
+
+// Report is a shareable summary of a run, rendered as HTML or Markdown
+type Report struct {
+	Command         string
+	GeneratedAt     string
+	Duration        string
+	OutputDirectory string
+	InventoryFile   string
+	InventoryHash   string
+	TrackerHash     string // Inventory hash recorded in the tracker at the last sync
+	TrackerLastSync string
+	Totals          []ReportStat
+	Sections        []ReportSection
+}
+
+// ReportStat is one headline number
+type ReportStat struct {
+	Label string
+	Value int
+}
+
+// ReportSection is a titled table; sections without rows are rendered as "None"
+type ReportSection struct {
+	Title   string
+	Columns []string
+	Rows    [][]string
+}
+
+// ReportFormat returns the format for a report path, based on its extension
+func ReportFormat(path string) (string, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".html", ".htm":
+		return ReportHTML, nil
+	case ".md", ".markdown":
+		return ReportMarkdown, nil
+	}
+	return "", fmt.Errorf("unsupported report file %s: use a .html or .md extension", path)
+}
+
+// NewReport creates a report and records the inventory and tracker hashes it was based on
+// Pass an empty inventoryFile or outputDir when the command does not use them
+func NewReport(command, outputDir, inventoryFile string) *Report {
+	report := &Report{
+		Command:         command,
+		GeneratedAt:     time.Now().Format(time.RFC3339),
+		OutputDirectory: outputDir,
+		InventoryFile:   inventoryFile,
+	}
+
+	if inventoryFile != "" {
+		if hash, err := CalculateInventoryHash(inventoryFile); err == nil {
+			report.InventoryHash = hash
+		}
+	}
+	if outputDir != "" && inventoryFile != "" {
+		if tracker, err := LoadOrCreateTracker(outputDir, inventoryFile); err == nil && tracker.InventoryHash != "" {
+			report.TrackerHash = tracker.InventoryHash
+			report.TrackerLastSync = tracker.LastSync
+		}
+	}
+
+	return report
+}
+
+// AddStat adds a headline number
+func (r *Report) AddStat(label string, value int) {
+	r.Totals = append(r.Totals, ReportStat{Label: label, Value: value})
+}
+
+// AddSection adds a table
+func (r *Report) AddSection(title string, columns []string, rows [][]string) {
+	r.Sections = append(r.Sections, ReportSection{Title: title, Columns: columns, Rows: rows})
+}
+
+// AddSummary adds the totals, failed and empty repositories and per-repository timings of a clone or pull
+func (r *Report) AddSummary(summary Summary) {
+	r.Duration = summary.TotalDuration
+
+	r.AddStat("Total", summary.TotalProjects)
+	r.AddStat("Successful", summary.SuccessCount)
+	r.AddStat("Failed", summary.FailureCount)
+	r.AddStat("Cloned", summary.ClonedCount)
+	r.AddStat("Updated", summary.UpdatedCount)
+	r.AddStat("Empty", summary.EmptyCount)
+	r.AddStat("Succeeded after retry", summary.RetriedCount)
+
+	var failed, empty, timings [][]string
+	for _, result := range summary.Results {
+		operation := "pull"
+		if result.IsClone {
+			operation = "clone"
+		}
+		timings = append(timings, []string{result.Project.Name, result.Project.Group, operation, result.Duration, fmt.Sprintf("%d", result.Attempts)})
+
+		switch {
+		case result.IsEmpty:
+			empty = append(empty, []string{result.Project.Name, result.Project.Group, result.Project.URL})
+		case !result.Success:
+			failed = append(failed, []string{result.Project.Name, result.Project.Group, result.Message})
+		}
+	}
+
+	r.AddSection("Failed", []string{"Project", "Group", "Error"}, failed)
+	r.AddSection("Empty", []string{"Project", "Group", "URL"}, empty)
+	r.AddSection("Timings", []string{"Project", "Group", "Operation", "Duration", "Attempts"}, timings)
+}
+
+// WriteReport renders the report to path as HTML or Markdown depending on the extension
+func WriteReport(path string, report *Report) error {
+	format, err := ReportFormat(path)
+	if err != nil {
+		return err
+	}
+
+	var content []byte
+	if format == ReportHTML {
+		content, err = renderHTMLReport(report)
+		if err != nil {
+			return fmt.Errorf("failed to render report: %w", err)
+		}
+	} else {
+		content = renderMarkdownReport(report)
+	}
+
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create report directory: %w", err)
+		}
+	}
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	return nil
+}
+
+// renderMarkdownReport renders the report as a Markdown document
+func renderMarkdownReport(report *Report) []byte {
+	var b bytes.Buffer
+
+	fmt.Fprintf(&b, "# syncx %s report\n\n", report.Command)
+	for _, line := range reportDetails(report) {
+		fmt.Fprintf(&b, "- **%s:** %s\n", line[0], markdownCell(line[1]))
+	}
+
+	b.WriteString("\n## Summary\n\n| | |\n|---|---:|\n")
+	for _, stat := range report.Totals {
+		fmt.Fprintf(&b, "| %s | %d |\n", stat.Label, stat.Value)
+	}
+
+	for _, section := range report.Sections {
+		fmt.Fprintf(&b, "\n## %s (%d)\n\n", section.Title, len(section.Rows))
+		if len(section.Rows) == 0 {
+			b.WriteString("None\n")
+			continue
+		}
+		fmt.Fprintf(&b, "| %s |\n", strings.Join(section.Columns, " | "))
+		fmt.Fprintf(&b, "|%s\n", strings.Repeat("---|", len(section.Columns)))
+		for _, row := range section.Rows {
+			cells := make([]string, len(row))
+			for i, cell := range row {
+				cells[i] = markdownCell(cell)
+			}
+			fmt.Fprintf(&b, "| %s |\n", strings.Join(cells, " | "))
+		}
+	}
+
+	return b.Bytes()
+}
+
+// markdownCell keeps a value on one table row
+func markdownCell(value string) string {
+	value = strings.ReplaceAll(value, "|", "\\|")
+	return strings.Join(strings.Fields(value), " ")
+}
+
+// reportDetails lists the run details shown at the top of the report
+func reportDetails(report *Report) [][2]string {
+	details := [][2]string{{"Generated", report.GeneratedAt}}
+	if report.Duration != "" {
+		details = append(details, [2]string{"Duration", report.Duration})
+	}
+	if report.OutputDirectory != "" {
+		details = append(details, [2]string{"Output directory", report.OutputDirectory})
+	}
+	if report.InventoryFile != "" {
+		details = append(details, [2]string{"Inventory", report.InventoryFile})
+	}
+	if report.InventoryHash != "" {
+		details = append(details, [2]string{"Inventory hash", report.InventoryHash})
+	}
+	if report.TrackerHash != "" {
+		details = append(details, [2]string{"Tracker hash", fmt.Sprintf("%s (last sync %s)", report.TrackerHash, report.TrackerLastSync)})
+	}
+	return details
+}
+
+var htmlReportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>syncx {{.Report.Command}} report</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2rem auto; max-width: 1100px; color: #1f2328; padding: 0 1rem; }
+h1 { font-size: 1.6rem; margin-bottom: 0.5rem; }
+h2 { font-size: 1.2rem; margin-top: 2rem; border-bottom: 1px solid #d0d7de; padding-bottom: 0.3rem; }
+dl { display: grid; grid-template-columns: max-content 1fr; gap: 0.25rem 1rem; }
+dt { font-weight: 600; }
+dd { margin: 0; font-family: ui-monospace, Menlo, Consolas, monospace; }
+.stats { display: flex; flex-wrap: wrap; gap: 0.75rem; }
+.stat { border: 1px solid #d0d7de; border-radius: 6px; padding: 0.5rem 1rem; min-width: 7rem; }
+.stat .value { font-size: 1.5rem; font-weight: 600; }
+.stat .label { color: #59636e; font-size: 0.85rem; }
+table { border-collapse: collapse; width: 100%; font-size: 0.9rem; }
+th, td { border: 1px solid #d0d7de; padding: 0.35rem 0.6rem; text-align: left; vertical-align: top; }
+th { background: #f6f8fa; }
+.none { color: #59636e; }
+</style>
+</head>
+<body>
+<h1>syncx {{.Report.Command}} report</h1>
+<dl>
+{{- range .Details}}
+<dt>{{index . 0}}</dt><dd>{{index . 1}}</dd>
+{{- end}}
+</dl>
+<h2>Summary</h2>
+<div class="stats">
+{{- range .Report.Totals}}
+<div class="stat"><div class="value">{{.Value}}</div><div class="label">{{.Label}}</div></div>
+{{- end}}
+</div>
+{{- range .Report.Sections}}
+<h2>{{.Title}} ({{len .Rows}})</h2>
+{{- if .Rows}}
+<table>
+<tr>{{range .Columns}}<th>{{.}}</th>{{end}}</tr>
+{{- range .Rows}}
+<tr>{{range .}}<td>{{.}}</td>{{end}}</tr>
+{{- end}}
+</table>
+{{- else}}
+<p class="none">None</p>
+{{- end}}
+{{- end}}
+</body>
+</html>
+`))
+
+// renderHTMLReport renders the report as a self-contained HTML page
+func renderHTMLReport(report *Report) ([]byte, error) {
+	var b bytes.Buffer
+	err := htmlReportTemplate.Execute(&b, struct {
+		Report  *Report
+		Details [][2]string
+	}{report, reportDetails(report)})
+	return b.Bytes(), err
+}
@@ -0,0 +1,165 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"strings"
+	"time"
+)
+
+// RetryPolicy controls how transient git failures are retried
+type RetryPolicy struct {
+	Retries   int           // Additional attempts after the first one
+	BaseDelay time.Duration // Delay before the first retry, doubled on each following retry
+	Jitter    float64       // Random spread applied to each delay (0.2 = ±20%)
+}
+
+// maxRetryDelay caps the exponential backoff
+const maxRetryDelay = 60 * time.Second
+
+var retryPolicy = RetryPolicy{
+	Retries:   2,
+	BaseDelay: 2 * time.Second,
+	Jitter:    0.2,
+}
+
+// SetRetryPolicy configures retries for all network git operations
+// The policy is validated by the caller (see the --retries and --retry-jitter flags)
+func SetRetryPolicy(policy RetryPolicy) {
+	retryPolicy = policy
+}
+
+// Git error classes
+const (
+	ErrorClassNetwork   = "network"
+	ErrorClassPermanent = "permanent"
+	ErrorClassUnknown   = "unknown"
+)
+
+// permanentErrorPatterns are stderr fragments that no amount of retrying will fix
+var permanentErrorPatterns = []string{
+	"permission denied",
+	"authentication failed",
+	"repository not found",
+	"does not appear to be a git repository",
+	"host key verification failed",
+	"could not read username",
+	"could not read password",
+	"access denied",
+	"returned error: 401",
+	"returned error: 403",
+	"returned error: 404",
+	"already exists and is not an empty directory",
+	"not possible to fast-forward",
+	"divergent branches",
+	"would be overwritten",
+	"conflict",
+}
+
+// networkErrorPatterns are stderr fragments of transient transport failures
+var networkErrorPatterns = []string{
+	"connection reset",
+	"connection timed out",
+	"connection refused",
+	"connection closed",
+	"operation timed out",
+	"could not resolve host",
+	"temporary failure in name resolution",
+	"network is unreachable",
+	"the remote end hung up unexpectedly",
+	"early eof",
+	"unexpected disconnect",
+	"rpc failed",
+	"transfer closed",
+	"gnutls_handshake",
+	"ssl_error",
+	"tls handshake",
+	"kex_exchange_identification",
+	"broken pipe",
+	"returned error: 429",
+	"returned error: 500",
+	"returned error: 502",
+	"returned error: 503",
+	"returned error: 504",
+}
+
+// ClassifyGitError tells transient network failures apart from permanent ones using git's stderr
+func ClassifyGitError(err error, stderr string) string {
+	if err == nil {
+		return ""
+	}
+
+	// Our own timeout killed the command: the remote was too slow, worth another try
+	if errors.Is(err, context.DeadlineExceeded) {
+		return ErrorClassNetwork
+	}
+
+	lower := strings.ToLower(stderr)
+	for _, pattern := range permanentErrorPatterns {
+		if strings.Contains(lower, pattern) {
+			return ErrorClassPermanent
+		}
+	}
+	for _, pattern := range networkErrorPatterns {
+		if strings.Contains(lower, pattern) {
+			return ErrorClassNetwork
+		}
+	}
+
+	return ErrorClassUnknown
+}
+
+// retryDelay returns the backoff before the given retry (1-based)
+func retryDelay(policy RetryPolicy, retry int) time.Duration {
+	if policy.BaseDelay <= 0 {
+		return 0
+	}
+	shift := uint(retry - 1)
+	delay := policy.BaseDelay << shift
+	if shift >= 63 || delay>>shift != policy.BaseDelay || delay > maxRetryDelay {
+		delay = maxRetryDelay // the doubling overflowed or went past the cap
+	}
+	if policy.Jitter > 0 {
+		spread := (rand.Float64()*2 - 1) * policy.Jitter
+		delay = time.Duration(float64(delay) * (1 + spread))
+	}
+	return delay
+}
+
+// countAttempts combines the attempts of the git commands making up one operation
+// Only network retries count: the first run of each command is part of the same attempt
+func countAttempts(commandAttempts ...int) int {
+	attempts := 1
+	for _, n := range commandAttempts {
+		if n > 1 {
+			attempts += n - 1
+		}
+	}
+	return attempts
+}
+
+// runGitNetworkCommand runs a git command that talks to a remote, retrying network-class failures
+// beforeRetry (optional) is called before every new attempt, e.g. to remove a partial clone
+// Returns the combined output of the last attempt and the number of attempts made
+func runGitNetworkCommand(timeout time.Duration, beforeRetry func(), args ...string) ([]byte, int, error) {
+	policy := retryPolicy
+	attempts := 0
+
+	for {
+		attempts++
+		output, stderr, err := runGitRemoteCommand(timeout, args...)
+		if err == nil {
+			return output, attempts, nil
+		}
+
+		if attempts > policy.Retries || ClassifyGitError(err, stderr) != ErrorClassNetwork {
+			return append(output, []byte(stderr)...), attempts, err
+		}
+
+		time.Sleep(retryDelay(policy, attempts))
+		if beforeRetry != nil {
+			beforeRetry()
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestClassifyGitError(t *testing.T) {
+	failed := errors.New("exit status 128")
+
+	tests := []struct {
+		name   string
+		err    error
+		stderr string
+		want   string
+	}{
+		{"no error", nil, "fatal: connection reset", ""},
+		{"our timeout", fmt.Errorf("timed out after 30s: %w", context.DeadlineExceeded), "", ErrorClassNetwork},
+		{"connection reset", failed, "fatal: read error: Connection reset by peer", ErrorClassNetwork},
+		{"dns failure", failed, "ssh: Could not resolve hostname gitlab.com", ErrorClassNetwork},
+		{"remote hung up", failed, "fatal: the remote end hung up unexpectedly", ErrorClassNetwork},
+		{"server error", failed, "error: RPC failed; The requested URL returned error: 502", ErrorClassNetwork},
+		{"rate limited", failed, "The requested URL returned error: 429", ErrorClassNetwork},
+		{"authentication", failed, "fatal: Authentication failed for 'https://gitlab.com/olive/api.git/'", ErrorClassPermanent},
+		{"missing repository", failed, "ERROR: Repository not found.\nfatal: Could not read from remote repository.", ErrorClassPermanent},
+		{"forbidden", failed, "The requested URL returned error: 403", ErrorClassPermanent},
+		{"diverged branches", failed, "fatal: Not possible to fast-forward, aborting.", ErrorClassPermanent},
+		{"permanent wins over network", failed, "Permission denied (publickey).\nfatal: the remote end hung up unexpectedly", ErrorClassPermanent},
+		{"unrecognized", failed, "fatal: something unexpected", ErrorClassUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ClassifyGitError(tt.err, tt.stderr); got != tt.want {
+				t.Errorf("ClassifyGitError() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy RetryPolicy
+		retry  int
+		want   time.Duration
+	}{
+		{"first retry uses the base delay", RetryPolicy{BaseDelay: 2 * time.Second}, 1, 2 * time.Second},
+		{"delay doubles", RetryPolicy{BaseDelay: 2 * time.Second}, 3, 8 * time.Second},
+		{"delay is capped", RetryPolicy{BaseDelay: 2 * time.Second}, 10, maxRetryDelay},
+		{"overflowing shift is capped", RetryPolicy{BaseDelay: 2 * time.Second}, 100, maxRetryDelay},
+		{"zero base delay never waits", RetryPolicy{BaseDelay: 0, Jitter: 0.5}, 5, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := retryDelay(tt.policy, tt.retry); got != tt.want {
+				t.Errorf("retryDelay() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryDelayJitter(t *testing.T) {
+	policy := RetryPolicy{BaseDelay: 10 * time.Second, Jitter: 0.2}
+	for i := 0; i < 100; i++ {
+		if got := retryDelay(policy, 1); got < 8*time.Second || got > 12*time.Second {
+			t.Fatalf("retryDelay() = %s, want within ±20%% of 10s", got)
+		}
+	}
+}
+
+func TestCountAttempts(t *testing.T) {
+	tests := []struct {
+		name     string
+		commands []int
+		want     int
+	}{
+		{"fetch and pull without retries", []int{1, 1}, 1},
+		{"ff-only fallback is not a retry", []int{1, 1, 1}, 1},
+		{"retried fetch", []int{3, 1}, 3},
+		{"retried pull and fallback", []int{1, 2, 2}, 3},
+		{"command that did not run", []int{0, 1}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := countAttempts(tt.commands...); got != tt.want {
+				t.Errorf("countAttempts(%v) = %d, want %d", tt.commands, got, tt.want)
+			}
+		})
+	}
+}
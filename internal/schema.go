@@ -0,0 +1,238 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// Schema versions of the files syncx reads and writes. Files written before
+// versioning existed have no schema_version field and are treated as version 1.
+const (
+	TrackerSchemaVersion   = 3
+	InventorySchemaVersion = 2
+)
+
+// SchemaVersionError reports a file written by a newer syncx than this one
+type SchemaVersionError struct {
+	Path      string
+	Version   int
+	Supported int
+}
+
+func (e *SchemaVersionError) Error() string {
+	return fmt.Sprintf("%s was written by a newer syncx (schema version %d, this syncx supports up to %d); upgrade syncx to use it",
+		e.Path, e.Version, e.Supported)
+}
+
+// persistMigrations is turned off for dry runs: older files are then only migrated in memory
+var persistMigrations = true
+
+// SetPersistMigrations controls whether migrated inventory and tracker files are written back
+func SetPersistMigrations(enabled bool) {
+	persistMigrations = enabled
+}
+
+// schemaMigration upgrades a raw JSON document by exactly one version
+type schemaMigration func(doc map[string]json.RawMessage) error
+
+// trackerMigrations are keyed by the version they upgrade from
+var trackerMigrations = map[int]schemaMigration{
+	// Version 2 only introduces the schema_version field itself
+	1: func(doc map[string]json.RawMessage) error { return nil },
+	2: migrateTrackerProjectIDs,
+}
+
+// inventoryMigrations are keyed by the version they upgrade from
+var inventoryMigrations = map[int]schemaMigration{
+	1: migrateInventoryPhysicalLocation,
+}
+
+// migrateTrackerProjectIDs gives every tracked project the stable identity it is matched by
+func migrateTrackerProjectIDs(doc map[string]json.RawMessage) error {
+	raw, ok := doc["projects"]
+	if !ok {
+		return nil
+	}
+	var projects []map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &projects); err != nil {
+		return err
+	}
+	for _, project := range projects {
+		var url string
+		json.Unmarshal(project["url"], &url)
+		id, err := json.Marshal(NormalizeRemoteURL(url))
+		if err != nil {
+			return err
+		}
+		project["id"] = id
+	}
+	migrated, err := json.Marshal(projects)
+	if err != nil {
+		return err
+	}
+	doc["projects"] = migrated
+	return nil
+}
+
+// migrateInventoryPhysicalLocation renames the misspelled phisical-location key
+func migrateInventoryPhysicalLocation(doc map[string]json.RawMessage) error {
+	legacy, ok := doc["phisical-location"]
+	if !ok {
+		return nil
+	}
+	if _, exists := doc["physical-location"]; !exists {
+		doc["physical-location"] = legacy
+	}
+	delete(doc, "phisical-location")
+	return nil
+}
+
+// migrateDocument applies forward migrations to a JSON document until it reaches
+// the target version. It returns the migrated document and the version it was read at.
+func migrateDocument(path string, data []byte, target int, migrations map[int]schemaMigration) ([]byte, int, error) {
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, 0, err
+	}
+
+	version := 1
+	if raw, ok := doc["schema_version"]; ok {
+		if err := json.Unmarshal(raw, &version); err != nil {
+			return nil, 0, fmt.Errorf("invalid schema_version: %w", err)
+		}
+	}
+	if version > target {
+		return nil, version, &SchemaVersionError{Path: path, Version: version, Supported: target}
+	}
+	if version == target {
+		return data, version, nil
+	}
+
+	for v := version; v < target; v++ {
+		migrate, ok := migrations[v]
+		if !ok {
+			return nil, version, fmt.Errorf("no migration from schema version %d", v)
+		}
+		if err := migrate(doc); err != nil {
+			return nil, version, fmt.Errorf("migration from schema version %d failed: %w", v, err)
+		}
+	}
+	doc["schema_version"] = json.RawMessage(fmt.Sprintf("%d", target))
+
+	migrated, err := marshalInOriginalOrder(data, doc)
+	if err != nil {
+		return nil, version, err
+	}
+	return migrated, version, nil
+}
+
+// marshalInOriginalOrder writes doc with the top-level keys in the order of the original
+// document, so a migrated file stays close to what the user wrote. A key that replaced a
+// removed one (a rename) takes its place; other new keys go last.
+func marshalInOriginalOrder(original []byte, doc map[string]json.RawMessage) ([]byte, error) {
+	var order []string
+	decoder := json.NewDecoder(bytes.NewReader(original))
+	if _, err := decoder.Token(); err != nil {
+		return nil, err
+	}
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		var skipped json.RawMessage
+		if err := decoder.Decode(&skipped); err != nil {
+			return nil, err
+		}
+		order = append(order, token.(string))
+	}
+
+	known := make(map[string]bool)
+	for _, key := range order {
+		known[key] = true
+	}
+	var added []string
+	for key := range doc {
+		if !known[key] && key != "schema_version" {
+			added = append(added, key)
+		}
+	}
+	sort.Strings(added)
+
+	var keys []string
+	for _, key := range order {
+		if _, ok := doc[key]; ok {
+			keys = append(keys, key)
+		} else if len(added) > 0 {
+			keys = append(keys, added[0])
+			added = added[1:]
+		}
+	}
+	keys = append(keys, added...)
+	if !known["schema_version"] {
+		keys = append(keys, "schema_version")
+	}
+
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		name, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(doc[key])
+	}
+	buf.WriteByte('}')
+
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, buf.Bytes(), "", "  "); err != nil {
+		return nil, err
+	}
+	return indented.Bytes(), nil
+}
+
+// persistMigration keeps the original file as <path>.v<version>.bak and writes
+// the migrated document in its place. It returns the backup path.
+func persistMigration(path string, original, migrated []byte, version int) (string, error) {
+	backupPath := fmt.Sprintf("%s.v%d.bak", path, version)
+	if err := os.WriteFile(backupPath, original, 0644); err != nil {
+		return "", fmt.Errorf("failed to back up %s: %w", path, err)
+	}
+	if err := os.WriteFile(path, migrated, 0644); err != nil {
+		return "", fmt.Errorf("failed to write migrated %s: %w", path, err)
+	}
+	return backupPath, nil
+}
+
+// MigrateTracker upgrades the tracker of an output directory to the current
+// schema version on disk, keeping a backup of the old file. It returns the
+// backup path, or an empty string when nothing had to be migrated or in a dry run.
+func MigrateTracker(outputDir string) (string, error) {
+	trackerPath := filepath.Join(outputDir, TrackingFileName)
+	data, err := ioutil.ReadFile(trackerPath)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read tracker file: %w", err)
+	}
+
+	migrated, version, err := migrateDocument(trackerPath, data, TrackerSchemaVersion, trackerMigrations)
+	if err != nil {
+		return "", err
+	}
+	if version == TrackerSchemaVersion || !persistMigrations {
+		return "", nil
+	}
+	return persistMigration(trackerPath, data, migrated, version)
+}
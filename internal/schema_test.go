@@ -0,0 +1,324 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// topLevelKeys returns the keys of a JSON object in document order
+func topLevelKeys(t *testing.T, data []byte) []string {
+	t.Helper()
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if _, err := decoder.Token(); err != nil {
+		t.Fatalf("not a JSON object: %v", err)
+	}
+	var keys []string
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			t.Fatalf("reading key: %v", err)
+		}
+		var skipped json.RawMessage
+		if err := decoder.Decode(&skipped); err != nil {
+			t.Fatalf("reading value: %v", err)
+		}
+		keys = append(keys, token.(string))
+	}
+	return keys
+}
+
+// withPersistMigrations sets whether migrations are written back for the duration of a test
+func withPersistMigrations(t *testing.T, enabled bool) {
+	t.Helper()
+	previous := persistMigrations
+	SetPersistMigrations(enabled)
+	t.Cleanup(func() { SetPersistMigrations(previous) })
+}
+
+const legacyTracker = `{
+  "last_sync": "2024-01-01T00:00:00Z",
+  "output_directory": "/tmp/repos",
+  "projects": [
+    {"name": "api", "url": "gitlab.com:olive/backend/api.git", "group": "Backend"}
+  ],
+  "inventory_hash": "abc"
+}`
+
+const legacyInventory = `{
+  "groups": [],
+  "phisical-location": "/tmp/repos",
+  "owner": "olive"
+}`
+
+func TestMigrateDocument(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		target     int
+		migrations map[int]schemaMigration
+		version    int
+		keys       []string
+	}{
+		{
+			name:       "tracker without a version is migrated to the current one",
+			input:      legacyTracker,
+			target:     TrackerSchemaVersion,
+			migrations: trackerMigrations,
+			version:    1,
+			keys:       []string{"last_sync", "output_directory", "projects", "inventory_hash", "schema_version"},
+		},
+		{
+			name:       "inventory rename keeps the key in place",
+			input:      legacyInventory,
+			target:     InventorySchemaVersion,
+			migrations: inventoryMigrations,
+			version:    1,
+			keys:       []string{"groups", "physical-location", "owner", "schema_version"},
+		},
+		{
+			name:       "explicit version keeps its position",
+			input:      `{"schema_version": 1, "groups": [], "phisical-location": "/tmp/repos"}`,
+			target:     InventorySchemaVersion,
+			migrations: inventoryMigrations,
+			version:    1,
+			keys:       []string{"schema_version", "groups", "physical-location"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			migrated, version, err := migrateDocument("test.json", []byte(tt.input), tt.target, tt.migrations)
+			if err != nil {
+				t.Fatalf("migrateDocument: %v", err)
+			}
+			if version != tt.version {
+				t.Errorf("version = %d, want %d", version, tt.version)
+			}
+			if keys := topLevelKeys(t, migrated); !reflect.DeepEqual(keys, tt.keys) {
+				t.Errorf("keys = %v, want %v", keys, tt.keys)
+			}
+
+			var doc struct {
+				SchemaVersion int `json:"schema_version"`
+			}
+			if err := json.Unmarshal(migrated, &doc); err != nil {
+				t.Fatalf("migrated document is not JSON: %v", err)
+			}
+			if doc.SchemaVersion != tt.target {
+				t.Errorf("schema_version = %d, want %d", doc.SchemaVersion, tt.target)
+			}
+		})
+	}
+}
+
+func TestMigrateDocumentRenamesPhysicalLocation(t *testing.T) {
+	migrated, _, err := migrateDocument("inventory.json", []byte(legacyInventory), InventorySchemaVersion, inventoryMigrations)
+	if err != nil {
+		t.Fatalf("migrateDocument: %v", err)
+	}
+	var inventory Inventory
+	if err := json.Unmarshal(migrated, &inventory); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if inventory.PhysicalLocation != "/tmp/repos" || inventory.LegacyPhysicalLocation != "" {
+		t.Errorf("physical-location = %q, phisical-location = %q", inventory.PhysicalLocation, inventory.LegacyPhysicalLocation)
+	}
+}
+
+func TestMigrateDocumentCurrentVersionUnchanged(t *testing.T) {
+	input, _ := json.Marshal(map[string]interface{}{"schema_version": InventorySchemaVersion, "groups": []string{}})
+	migrated, version, err := migrateDocument("inventory.json", input, InventorySchemaVersion, inventoryMigrations)
+	if err != nil {
+		t.Fatalf("migrateDocument: %v", err)
+	}
+	if version != InventorySchemaVersion || !bytes.Equal(migrated, input) {
+		t.Errorf("got version %d and %s, want the input unchanged", version, migrated)
+	}
+}
+
+func TestMigrateDocumentRefusesNewerVersion(t *testing.T) {
+	tests := []struct {
+		name       string
+		target     int
+		migrations map[int]schemaMigration
+	}{
+		{"tracker", TrackerSchemaVersion, trackerMigrations},
+		{"inventory", InventorySchemaVersion, inventoryMigrations},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			newer := tt.target + 1
+			input, _ := json.Marshal(map[string]int{"schema_version": newer})
+			_, _, err := migrateDocument("file.json", input, tt.target, tt.migrations)
+
+			var versionErr *SchemaVersionError
+			if !errors.As(err, &versionErr) {
+				t.Fatalf("err = %v, want a SchemaVersionError", err)
+			}
+			if versionErr.Path != "file.json" || versionErr.Version != newer || versionErr.Supported != tt.target {
+				t.Errorf("err = %+v", versionErr)
+			}
+		})
+	}
+}
+
+func TestMigrateTracker(t *testing.T) {
+	tests := []struct {
+		name    string
+		persist bool
+	}{
+		{"writes the migrated file and a backup", true},
+		{"dry run writes nothing", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withPersistMigrations(t, tt.persist)
+			dir := t.TempDir()
+			trackerPath := filepath.Join(dir, TrackingFileName)
+			if err := os.WriteFile(trackerPath, []byte(legacyTracker), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			backupPath, err := MigrateTracker(dir)
+			if err != nil {
+				t.Fatalf("MigrateTracker: %v", err)
+			}
+			data, err := os.ReadFile(trackerPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !tt.persist {
+				if backupPath != "" {
+					t.Errorf("backup = %q, want none in a dry run", backupPath)
+				}
+				if string(data) != legacyTracker {
+					t.Errorf("tracker was rewritten in a dry run:\n%s", data)
+				}
+				if matches, _ := filepath.Glob(filepath.Join(dir, "*.bak")); len(matches) > 0 {
+					t.Errorf("dry run wrote %v", matches)
+				}
+				return
+			}
+
+			if backupPath != trackerPath+".v1.bak" {
+				t.Errorf("backup = %q", backupPath)
+			}
+			if backup, _ := os.ReadFile(backupPath); string(backup) != legacyTracker {
+				t.Errorf("backup does not hold the original tracker:\n%s", backup)
+			}
+			var tracker ProjectTracker
+			if err := json.Unmarshal(data, &tracker); err != nil {
+				t.Fatalf("migrated tracker: %v", err)
+			}
+			if tracker.SchemaVersion != TrackerSchemaVersion || len(tracker.Projects) != 1 {
+				t.Errorf("migrated tracker = %+v", tracker)
+			}
+
+			// A second run has nothing left to migrate
+			if backupPath, err := MigrateTracker(dir); err != nil || backupPath != "" {
+				t.Errorf("second MigrateTracker = %q, %v", backupPath, err)
+			}
+		})
+	}
+}
+
+func TestLoadInventoryMigration(t *testing.T) {
+	tests := []struct {
+		name    string
+		persist bool
+	}{
+		{"writes the migrated file and a backup", true},
+		{"dry run writes nothing", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withPersistMigrations(t, tt.persist)
+			path := filepath.Join(t.TempDir(), "inventory.json")
+			if err := os.WriteFile(path, []byte(legacyInventory), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			inventory, err := LoadInventory(path)
+			if err != nil {
+				t.Fatalf("LoadInventory: %v", err)
+			}
+			if inventory.PhysicalLocation != "/tmp/repos" {
+				t.Errorf("PhysicalLocation = %q", inventory.PhysicalLocation)
+			}
+
+			data, _ := os.ReadFile(path)
+			_, backupErr := os.Stat(path + ".v1.bak")
+			if tt.persist {
+				if backupErr != nil {
+					t.Errorf("no backup: %v", backupErr)
+				}
+				if keys := topLevelKeys(t, data); !reflect.DeepEqual(keys, []string{"groups", "physical-location", "owner", "schema_version"}) {
+					t.Errorf("keys = %v", keys)
+				}
+			} else {
+				if backupErr == nil {
+					t.Error("dry run wrote a backup")
+				}
+				if string(data) != legacyInventory {
+					t.Errorf("inventory was rewritten in a dry run:\n%s", data)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadRefusesNewerSchema(t *testing.T) {
+	dir := t.TempDir()
+
+	trackerData, _ := json.Marshal(map[string]int{"schema_version": TrackerSchemaVersion + 1})
+	if err := os.WriteFile(filepath.Join(dir, TrackingFileName), trackerData, 0644); err != nil {
+		t.Fatal(err)
+	}
+	var versionErr *SchemaVersionError
+	if _, err := LoadOrCreateTracker(dir, ""); !errors.As(err, &versionErr) {
+		t.Errorf("LoadOrCreateTracker err = %v, want a SchemaVersionError", err)
+	}
+
+	inventoryPath := filepath.Join(dir, "inventory.json")
+	inventoryData, _ := json.Marshal(map[string]int{"schema_version": InventorySchemaVersion + 1})
+	if err := os.WriteFile(inventoryPath, inventoryData, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadInventory(inventoryPath); !errors.As(err, &versionErr) {
+		t.Errorf("LoadInventory err = %v, want a SchemaVersionError", err)
+	}
+}
+
+func TestMigrateTrackerProjectIDs(t *testing.T) {
+	for _, version := range []string{`"schema_version": 2,`, ""} {
+		input := `{` + version + `"projects": [
+  {"name": "api", "url": "gitlab.com:olive/backend/api.git"},
+  {"name": "app", "url": "https://gitlab.com/olive/frontend/app"}
+]}`
+		migrated, _, err := migrateDocument("tracker.json", []byte(input), TrackerSchemaVersion, trackerMigrations)
+		if err != nil {
+			t.Fatalf("migrateDocument: %v", err)
+		}
+
+		var tracker ProjectTracker
+		if err := json.Unmarshal(migrated, &tracker); err != nil {
+			t.Fatalf("unmarshal: %v", err)
+		}
+		var ids []string
+		for _, project := range tracker.Projects {
+			ids = append(ids, project.ID)
+		}
+		if want := []string{"gitlab.com/olive/backend/api", "gitlab.com/olive/frontend/app"}; !reflect.DeepEqual(ids, want) {
+			t.Errorf("from %q: ids = %v, want %v", version, ids, want)
+		}
+	}
+}
@@ -0,0 +1,225 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SnapshotSchemaVersion is the version of the snapshot files syncx writes
+const SnapshotSchemaVersion = 1
+
+// Snapshot records the exact commit of every tracked repository of an output directory
+type Snapshot struct {
+	SchemaVersion   int               `json:"schema_version"`
+	CreatedAt       string            `json:"created_at"`
+	OutputDirectory string            `json:"output_directory"`
+	Projects        []SnapshotProject `json:"projects"`
+}
+
+// SnapshotProject is one repository in a snapshot. Path is relative to the output directory
+// so a snapshot can be restored into another workspace; Branch is empty for a detached HEAD.
+type SnapshotProject struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Group  string `json:"group"`
+	URL    string `json:"url"`
+	Path   string `json:"path"`
+	Branch string `json:"branch,omitempty"`
+	Commit string `json:"commit"`
+}
+
+// Kinds of difference between two snapshots
+const (
+	SnapshotAdded   = "added"
+	SnapshotRemoved = "removed"
+	SnapshotChanged = "changed"
+)
+
+// SnapshotDifference is a repository that is not at the same commit in two snapshots
+type SnapshotDifference struct {
+	ID         string `json:"id"`
+	Project    string `json:"project"`
+	Group      string `json:"group"`
+	Kind       string `json:"kind"`
+	FromCommit string `json:"from_commit,omitempty"`
+	ToCommit   string `json:"to_commit,omitempty"`
+	FromBranch string `json:"from_branch,omitempty"`
+	ToBranch   string `json:"to_branch,omitempty"`
+}
+
+// NewSnapshot creates an empty snapshot of an output directory
+func NewSnapshot(outputDir string) *Snapshot {
+	return &Snapshot{
+		SchemaVersion:   SnapshotSchemaVersion,
+		CreatedAt:       time.Now().Format(time.RFC3339),
+		OutputDirectory: outputDir,
+		Projects:        []SnapshotProject{},
+	}
+}
+
+// SnapshotTrackedProject records the branch and commit a tracked repository is at
+func SnapshotTrackedProject(tracked TrackedProject, outputDir string) (SnapshotProject, error) {
+	commit, err := GetCurrentCommitHash(tracked.LocalPath)
+	if err != nil || commit == "" {
+		return SnapshotProject{}, fmt.Errorf("no commit checked out")
+	}
+	branch, _ := GetGitBranch(tracked.LocalPath)
+
+	path := tracked.LocalPath
+	if rel, err := filepath.Rel(outputDir, tracked.LocalPath); err == nil && !strings.HasPrefix(rel, "..") {
+		path = filepath.ToSlash(rel)
+	}
+
+	return SnapshotProject{
+		ID:     trackedIdentity(tracked),
+		Name:   tracked.Name,
+		Group:  tracked.Group,
+		URL:    tracked.URL,
+		Path:   path,
+		Branch: branch,
+		Commit: commit,
+	}, nil
+}
+
+// SaveSnapshot writes a snapshot file
+func SaveSnapshot(path string, snapshot *Snapshot) error {
+	data, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal snapshot: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	return nil
+}
+
+// LoadSnapshot reads a snapshot file, refusing one written by a newer syncx
+func LoadSnapshot(path string) (*Snapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+	data, _, err = migrateDocument(path, data, SnapshotSchemaVersion, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshot Snapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot %s: %w", path, err)
+	}
+	return &snapshot, nil
+}
+
+// SnapshotProjectPath is where a snapshot repository lives in an output directory
+func SnapshotProjectPath(project SnapshotProject, outputDir string) string {
+	if filepath.IsAbs(project.Path) {
+		return project.Path
+	}
+	return filepath.Join(outputDir, filepath.FromSlash(project.Path))
+}
+
+// DiffSnapshots lists the repositories whose commit or branch differs between two snapshots,
+// sorted by group and name
+func DiffSnapshots(a, b *Snapshot) []SnapshotDifference {
+	before := make(map[string]SnapshotProject)
+	for _, project := range a.Projects {
+		before[project.ID] = project
+	}
+
+	var differences []SnapshotDifference
+	seen := make(map[string]bool)
+	for _, project := range b.Projects {
+		seen[project.ID] = true
+		old, ok := before[project.ID]
+		switch {
+		case !ok:
+			differences = append(differences, SnapshotDifference{ID: project.ID, Project: project.Name, Group: project.Group,
+				Kind: SnapshotAdded, ToCommit: project.Commit, ToBranch: project.Branch})
+		case old.Commit != project.Commit || old.Branch != project.Branch:
+			differences = append(differences, SnapshotDifference{ID: project.ID, Project: project.Name, Group: project.Group,
+				Kind: SnapshotChanged, FromCommit: old.Commit, ToCommit: project.Commit, FromBranch: old.Branch, ToBranch: project.Branch})
+		}
+	}
+	for _, project := range a.Projects {
+		if !seen[project.ID] {
+			differences = append(differences, SnapshotDifference{ID: project.ID, Project: project.Name, Group: project.Group,
+				Kind: SnapshotRemoved, FromCommit: project.Commit, FromBranch: project.Branch})
+		}
+	}
+
+	sort.Slice(differences, func(i, j int) bool {
+		if differences[i].Group != differences[j].Group {
+			return differences[i].Group < differences[j].Group
+		}
+		return differences[i].Project < differences[j].Project
+	})
+	return differences
+}
+
+// hasCommit reports whether a repository has a commit object locally
+func hasCommit(localPath, commit string) bool {
+	_, err := RunGitCommand("-C", localPath, "cat-file", "-e", commit+"^{commit}")
+	return err == nil
+}
+
+// EnsureCommit fetches a commit that is not in the repository yet. Shallow clones are
+// deepened when fetching the commit alone is not enough.
+func EnsureCommit(localPath, commit string, timeout time.Duration) error {
+	if hasCommit(localPath, commit) {
+		return nil
+	}
+
+	runGitNetworkCommand(fetchTimeout(timeout), nil, "-C", localPath, "fetch", "--quiet", "origin", commit)
+	if hasCommit(localPath, commit) {
+		return nil
+	}
+
+	if output, err := RunGitCommand("-C", localPath, "rev-parse", "--is-shallow-repository"); err == nil && strings.TrimSpace(string(output)) == "true" {
+		if output, _, err := runGitNetworkCommand(fetchTimeout(timeout), nil, "-C", localPath, "fetch", "--quiet", "--unshallow"); err != nil {
+			return fmt.Errorf("fetch --unshallow failed: %v%s", err, gitErrorDetail(output))
+		}
+	} else if err := fetchAllBranches(localPath, timeout); err != nil {
+		return err
+	}
+	if !hasCommit(localPath, commit) {
+		return fmt.Errorf("commit %s is not on the remote", shortCommit(commit))
+	}
+	return nil
+}
+
+// CheckoutCommit checks out a commit, detached or with branch reset to it. A branch that
+// exists on origin tracks it, so a later pull fast-forwards it again.
+func CheckoutCommit(localPath, commit, branch string) error {
+	args := []string{"-C", localPath, "checkout", "--quiet", "--detach", commit}
+	if branch != "" {
+		args = []string{"-C", localPath, "checkout", "--quiet", "-B", branch, commit}
+	}
+	if output, err := RunGitCommand(args...); err != nil {
+		return fmt.Errorf("git checkout failed%s", gitErrorDetail(output))
+	}
+	if branch != "" {
+		RunGitCommand("-C", localPath, "branch", "--quiet", "--set-upstream-to=origin/"+branch, branch)
+	}
+	return nil
+}
+
+// CommitsDroppedFromBranch counts the commits of a local branch that neither a remote nor the
+// target commit contains, i.e. what resetting the branch to the commit would lose
+func CommitsDroppedFromBranch(localPath, branch, commit string) int {
+	if _, err := RunGitCommand("-C", localPath, "rev-parse", "--verify", "--quiet", "refs/heads/"+branch); err != nil {
+		return 0
+	}
+	output, err := RunGitCommand("-C", localPath, "rev-list", "--count", "refs/heads/"+branch, "--not", "--remotes", commit)
+	if err != nil {
+		return 0
+	}
+	var count int
+	fmt.Sscanf(strings.TrimSpace(string(output)), "%d", &count)
+	return count
+}
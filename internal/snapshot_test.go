@@ -0,0 +1,69 @@
+package internal
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestDiffSnapshots(t *testing.T) {
+	before := &Snapshot{Projects: []SnapshotProject{
+		{ID: "gitlab.com/olive/api", Name: "api", Group: "Backend", Branch: "main", Commit: "a1"},
+		{ID: "gitlab.com/olive/auth", Name: "auth", Group: "Backend", Branch: "main", Commit: "b1"},
+		{ID: "gitlab.com/olive/app", Name: "app", Group: "Frontend", Branch: "main", Commit: "c1"},
+		{ID: "gitlab.com/olive/old", Name: "old", Group: "Backend", Commit: "d1"},
+		{ID: "gitlab.com/olive/web", Name: "web", Group: "Frontend", Branch: "main", Commit: "e1"},
+	}}
+	after := &Snapshot{Projects: []SnapshotProject{
+		{ID: "gitlab.com/olive/web", Name: "web", Group: "Frontend", Branch: "main", Commit: "e1"},
+		{ID: "gitlab.com/olive/api", Name: "api", Group: "Backend", Branch: "main", Commit: "a2"},
+		{ID: "gitlab.com/olive/auth", Name: "auth", Group: "Backend", Branch: "release", Commit: "b1"},
+		{ID: "gitlab.com/olive/app", Name: "app", Group: "Frontend", Commit: "c1"},
+		{ID: "gitlab.com/olive/new", Name: "new", Group: "Backend", Branch: "main", Commit: "f1"},
+	}}
+
+	want := []SnapshotDifference{
+		{ID: "gitlab.com/olive/api", Project: "api", Group: "Backend", Kind: SnapshotChanged, FromCommit: "a1", ToCommit: "a2", FromBranch: "main", ToBranch: "main"},
+		{ID: "gitlab.com/olive/auth", Project: "auth", Group: "Backend", Kind: SnapshotChanged, FromCommit: "b1", ToCommit: "b1", FromBranch: "main", ToBranch: "release"},
+		{ID: "gitlab.com/olive/new", Project: "new", Group: "Backend", Kind: SnapshotAdded, ToCommit: "f1", ToBranch: "main"},
+		{ID: "gitlab.com/olive/old", Project: "old", Group: "Backend", Kind: SnapshotRemoved, FromCommit: "d1"},
+		{ID: "gitlab.com/olive/app", Project: "app", Group: "Frontend", Kind: SnapshotChanged, FromCommit: "c1", ToCommit: "c1", FromBranch: "main"},
+	}
+	if got := DiffSnapshots(before, after); !reflect.DeepEqual(got, want) {
+		t.Errorf("DiffSnapshots() =\n%+v\nwant\n%+v", got, want)
+	}
+
+	if got := DiffSnapshots(after, after); len(got) != 0 {
+		t.Errorf("DiffSnapshots() of identical snapshots = %+v, want none", got)
+	}
+}
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+	snapshot := NewSnapshot("/tmp/repos")
+	snapshot.Projects = append(snapshot.Projects, SnapshotProject{ID: "gitlab.com/olive/api", Name: "api", Path: "projects/olive/api", Commit: "a1"})
+
+	if err := SaveSnapshot(path, snapshot); err != nil {
+		t.Fatalf("SaveSnapshot: %v", err)
+	}
+	loaded, err := LoadSnapshot(path)
+	if err != nil {
+		t.Fatalf("LoadSnapshot: %v", err)
+	}
+	if !reflect.DeepEqual(loaded, snapshot) {
+		t.Errorf("loaded %+v, want %+v", loaded, snapshot)
+	}
+	if got := SnapshotProjectPath(loaded.Projects[0], "/srv/workspace"); got != filepath.Join("/srv/workspace", "projects", "olive", "api") {
+		t.Errorf("SnapshotProjectPath() = %q", got)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"schema_version": 99, "projects": []}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	var versionErr *SchemaVersionError
+	if _, err := LoadSnapshot(path); !errors.As(err, &versionErr) {
+		t.Errorf("LoadSnapshot of a newer snapshot: err = %v, want a SchemaVersionError", err)
+	}
+}
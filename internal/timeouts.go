@@ -0,0 +1,89 @@
+package internal
+
+import (
+	"time"
+)
+
+// Timeouts holds the default budgets for network git operations
+type Timeouts struct {
+	Clone    time.Duration // Budget for a single clone attempt
+	Fetch    time.Duration // Budget for a single fetch or pull attempt
+	Adaptive bool          // Grow budgets for repositories that previously took long
+}
+
+// adaptiveTimeoutFactor is how much headroom a slow repository gets over its last recorded duration
+const adaptiveTimeoutFactor = 3
+
+var timeouts = Timeouts{
+	Clone:    60 * time.Second,
+	Fetch:    30 * time.Second,
+	Adaptive: true,
+}
+
+// SetTimeouts configures the default budgets for network git operations
+func SetTimeouts(t Timeouts) {
+	if t.Clone <= 0 {
+		t.Clone = 60 * time.Second
+	}
+	if t.Fetch <= 0 {
+		t.Fetch = 30 * time.Second
+	}
+	timeouts = t
+}
+
+// cloneTimeout returns the clone budget, falling back to the global default
+func cloneTimeout(timeout time.Duration) time.Duration {
+	if timeout > 0 {
+		return timeout
+	}
+	return timeouts.Clone
+}
+
+// fetchTimeout returns the fetch/pull budget, falling back to the global default
+func fetchTimeout(timeout time.Duration) time.Duration {
+	if timeout > 0 {
+		return timeout
+	}
+	return timeouts.Fetch
+}
+
+// ApplyTimeouts resolves the effective clone and fetch budgets for each project
+// Inventory overrides replace the global defaults; with adaptive timeouts enabled,
+// repositories whose last recorded clone/pull took long get a proportionally larger budget
+func ApplyTimeouts(projects []ProjectInfo, tracker *ProjectTracker) []ProjectInfo {
+	durations := make(map[string]TrackedProject)
+	if tracker != nil {
+		for identity, index := range MatchTrackedProjects(tracker, projects) {
+			durations[identity] = tracker.Projects[index]
+		}
+	}
+
+	resolved := make([]ProjectInfo, len(projects))
+	for i, project := range projects {
+		project.CloneTimeout = cloneTimeout(project.CloneTimeout)
+		project.FetchTimeout = fetchTimeout(project.FetchTimeout)
+
+		if tracked, ok := durations[ProjectIdentity(project)]; ok && timeouts.Adaptive {
+			project.CloneTimeout = adaptTimeout(project.CloneTimeout, tracked.LastCloneDuration)
+			project.FetchTimeout = adaptTimeout(project.FetchTimeout, tracked.LastPullDuration)
+		}
+
+		resolved[i] = project
+	}
+	return resolved
+}
+
+// adaptTimeout grows a budget to leave headroom over a previously recorded duration
+func adaptTimeout(timeout time.Duration, recorded string) time.Duration {
+	if recorded == "" {
+		return timeout
+	}
+	previous, err := time.ParseDuration(recorded)
+	if err != nil {
+		return timeout
+	}
+	if scaled := previous * adaptiveTimeoutFactor; scaled > timeout {
+		return scaled
+	}
+	return timeout
+}
@@ -0,0 +1,117 @@
+package internal
+
+import (
+	"testing"
+	"time"
+)
+
+// withTimeouts sets the default budgets for the duration of a test
+func withTimeouts(t *testing.T, defaults Timeouts) {
+	t.Helper()
+	previous := timeouts
+	SetTimeouts(defaults)
+	t.Cleanup(func() { timeouts = previous })
+}
+
+// findTestProject returns the tracker entry of a project by name
+func findTestProject(tracker *ProjectTracker, name string) *TrackedProject {
+	for i := range tracker.Projects {
+		if tracker.Projects[i].Name == name {
+			return &tracker.Projects[i]
+		}
+	}
+	return nil
+}
+
+func TestApplyTimeouts(t *testing.T) {
+	withTimeouts(t, Timeouts{Clone: time.Minute, Fetch: 30 * time.Second, Adaptive: true})
+
+	tracker := &ProjectTracker{Projects: []TrackedProject{
+		{Name: "slow", URL: "gitlab.com:olive/slow.git", LastCloneDuration: "40s", LastPullDuration: "20s"},
+		{Name: "fast", URL: "gitlab.com:olive/fast.git", LastCloneDuration: "2s", LastPullDuration: "1s"},
+		{Name: "broken", URL: "gitlab.com:olive/broken.git", LastCloneDuration: "soon"},
+	}}
+	projects := []ProjectInfo{
+		{Name: "slow", URL: "gitlab.com:olive/slow.git"},
+		{Name: "fast", URL: "gitlab.com:olive/fast.git"},
+		{Name: "broken", URL: "gitlab.com:olive/broken.git"},
+		{Name: "pinned", URL: "gitlab.com:olive/pinned.git", CloneTimeout: 10 * time.Minute},
+		{Name: "new", URL: "gitlab.com:olive/new.git"},
+	}
+
+	want := map[string][2]time.Duration{
+		"slow":   {2 * time.Minute, time.Minute},
+		"fast":   {time.Minute, 30 * time.Second},
+		"broken": {time.Minute, 30 * time.Second},
+		"pinned": {10 * time.Minute, 30 * time.Second},
+		"new":    {time.Minute, 30 * time.Second},
+	}
+	for _, project := range ApplyTimeouts(projects, tracker) {
+		if got := [2]time.Duration{project.CloneTimeout, project.FetchTimeout}; got != want[project.Name] {
+			t.Errorf("%s: clone/fetch budgets = %v, want %v", project.Name, got, want[project.Name])
+		}
+	}
+
+	timeouts.Adaptive = false
+	for _, project := range ApplyTimeouts(projects[:1], tracker) {
+		if project.CloneTimeout != time.Minute || project.FetchTimeout != 30*time.Second {
+			t.Errorf("adaptive timeouts disabled: got %s/%s", project.CloneTimeout, project.FetchTimeout)
+		}
+	}
+}
+
+func TestRecordResultsInTrackerTimedOut(t *testing.T) {
+	withTimeouts(t, Timeouts{Clone: time.Minute, Fetch: 30 * time.Second, Adaptive: true})
+	dir := t.TempDir()
+
+	tracker, err := LoadOrCreateTracker(dir, "inventory.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tracker.Projects = append(tracker.Projects, TrackedProject{Name: "api", URL: "gitlab.com:olive/api.git", LastPullDuration: "5s"})
+	if err := SaveTracker(tracker); err != nil {
+		t.Fatal(err)
+	}
+
+	api := ProjectInfo{Name: "api", URL: "gitlab.com:olive/api.git", FetchTimeout: 45 * time.Second}
+	monorepo := ProjectInfo{Name: "monorepo", URL: "gitlab.com:olive/monorepo.git"}
+	results := []OperationResult{
+		{Project: api, TimedOut: true, Duration: "2m15s"},
+		{Project: monorepo, IsClone: true, TimedOut: true, Duration: "3m"},
+		{Project: ProjectInfo{Name: "auth", URL: "gitlab.com:olive/auth.git"}, IsClone: true, Duration: "1s"}, // failed without a timeout
+	}
+	if err := RecordResultsInTracker(dir, "inventory.json", results); err != nil {
+		t.Fatalf("RecordResultsInTracker: %v", err)
+	}
+
+	tracker, err = LoadOrCreateTracker(dir, "inventory.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tracker.Projects) != 2 {
+		t.Fatalf("tracker has %d projects, want api and the timed-out monorepo clone", len(tracker.Projects))
+	}
+	if tracked := findTestProject(tracker, "api"); tracked == nil || tracked.LastPullDuration != "45s" {
+		t.Errorf("api: want the exhausted 45s fetch budget recorded, got %+v", tracker.Projects)
+	}
+	if tracked := findTestProject(tracker, "monorepo"); tracked == nil || tracked.LastCloneDuration != "1m0s" {
+		t.Errorf("monorepo: want the exhausted 1m clone budget recorded, got %+v", tracker.Projects)
+	}
+
+	// The next run gets a larger budget
+	for _, project := range ApplyTimeouts([]ProjectInfo{{Name: "monorepo", URL: monorepo.URL}}, tracker) {
+		if project.CloneTimeout != 3*time.Minute {
+			t.Errorf("next clone budget = %s, want 3m", project.CloneTimeout)
+		}
+	}
+
+	// A shorter timeout never lowers what was recorded
+	results = []OperationResult{{Project: ProjectInfo{Name: "api", URL: api.URL, FetchTimeout: 10 * time.Second}, TimedOut: true}}
+	if err := RecordResultsInTracker(dir, "inventory.json", results); err != nil {
+		t.Fatal(err)
+	}
+	tracker, _ = LoadOrCreateTracker(dir, "inventory.json")
+	if tracked := findTestProject(tracker, "api"); tracked == nil || tracked.LastPullDuration != "45s" {
+		t.Errorf("api: recorded duration was lowered: %+v", tracker.Projects)
+	}
+}
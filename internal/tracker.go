@@ -6,8 +6,8 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
-	"os/exec"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 )
@@ -36,30 +36,43 @@ func LoadOrCreateTracker(outputDir, inventoryFile string) (*ProjectTracker, erro
 			return nil, fmt.Errorf("failed to read tracker file: %w", err)
 		}
 		
+		// Older trackers are migrated in memory; MigrateTracker upgrades the file itself
+		migrated, _, err := migrateDocument(trackerPath, data, TrackerSchemaVersion, trackerMigrations)
+		if err != nil {
+			if _, newer := err.(*SchemaVersionError); newer {
+				return nil, err
+			}
+			return nil, fmt.Errorf("failed to parse tracker file: %w", err)
+		}
+
 		var tracker ProjectTracker
-		if err := json.Unmarshal(data, &tracker); err != nil {
+		if err := json.Unmarshal(migrated, &tracker); err != nil {
 			return nil, fmt.Errorf("failed to parse tracker file: %w", err)
 		}
 		
 		return &tracker, nil
 	}
 	
-	// Create new tracker
-	tracker := &ProjectTracker{
+	return NewTracker(outputDir, inventoryFile), nil
+}
+
+// NewTracker creates an empty tracker for the output directory
+func NewTracker(outputDir, inventoryFile string) *ProjectTracker {
+	return &ProjectTracker{
+		SchemaVersion:   TrackerSchemaVersion,
 		LastSync:        time.Now().Format(time.RFC3339),
 		OutputDirectory: outputDir,
 		InventoryFile:   inventoryFile,
 		Projects:        []TrackedProject{},
 	}
-	
-	return tracker, nil
 }
 
 // SaveTracker saves the tracker to disk
 func SaveTracker(tracker *ProjectTracker) error {
 	trackerPath := filepath.Join(tracker.OutputDirectory, TrackingFileName)
 	
-	// Update last sync time
+	// Update last sync time and stamp the schema this syncx writes
+	tracker.SchemaVersion = TrackerSchemaVersion
 	tracker.LastSync = time.Now().Format(time.RFC3339)
 	
 	// Marshal to JSON with proper formatting
@@ -105,19 +118,15 @@ func CompareWithInventory(tracker *ProjectTracker, currentProjects []ProjectInfo
 		RemovedProjects:   []ProjectInfo{},
 		ModifiedProjects:  []ProjectInfo{},
 		UnchangedProjects: []ProjectInfo{},
+		PreviousPaths:     make(map[string]string),
 	}
 	
-	// Create maps for quick lookup
-	trackedMap := make(map[string]TrackedProject)
-	for _, tracked := range tracker.Projects {
-		key := fmt.Sprintf("%s|%s", tracked.Name, tracked.URL)
-		trackedMap[key] = tracked
-	}
+	// Match tracked projects by stable identity, so URL spelling, protocol and namespace
+	// changes are not mistaken for a new project that gets cloned a second time
+	matches := MatchTrackedProjects(tracker, currentProjects)
+	matchedTracked := make(map[int]bool)
 	
-	currentMap := make(map[string]ProjectInfo)
 	for _, current := range currentProjects {
-		key := fmt.Sprintf("%s|%s", current.Name, current.URL)
-		
 		// Populate ProjectInfo with local path and git URL using improved logic
 		current.GitURL = FormatGitURL(current.URL, protocol)
 		current.LocalPath = CreateProjectLocalPath(outputDir, current.URL, current.Group)
@@ -128,21 +137,45 @@ func CompareWithInventory(tracker *ProjectTracker, currentProjects []ProjectInfo
 			continue
 		}
 		
-		currentMap[key] = current
-	}
-	
-	// Find new projects (in current but not in tracked)
-	for key, current := range currentMap {
-		if _, exists := trackedMap[key]; !exists {
+		// Find new projects (in current but not in tracked)
+		index, exists := matches[ProjectIdentity(current)]
+		if !exists {
 			diff.NewProjects = append(diff.NewProjects, current)
+			continue
+		}
+		matchedTracked[index] = true
+		
+		// Adopt the inventory's URL and name for a project that moved
+		tracked := &tracker.Projects[index]
+		if tracked.URL != current.URL {
+			logger.Info("🔗 URL of %s changed: %s → %s", current.Name, tracked.URL, current.URL)
+		}
+		tracked.ID = ProjectIdentity(current)
+		tracked.Name = current.Name
+		tracked.URL = current.URL
+		
+		// Check if the project has been moved
+		if tracked.LocalPath != current.LocalPath {
+			// Project path changed, treat as modified
+			diff.ModifiedProjects = append(diff.ModifiedProjects, current)
+			diff.PreviousPaths[ProjectIdentity(current)] = tracked.LocalPath
+		} else {
+			diff.UnchangedProjects = append(diff.UnchangedProjects, current)
 		}
 	}
 	
 	// Find removed projects (in tracked but not in current)
-	for key, tracked := range trackedMap {
-		if _, exists := currentMap[key]; !exists {
+	orphanedCount := 0
+	for index, tracked := range tracker.Projects {
+		if !matchedTracked[index] {
+			// Reported on an earlier run; the clone is left for 'syncx prune'
+			if tracked.Status == "orphaned" && IsGitRepository(tracked.LocalPath) {
+				orphanedCount++
+				continue
+			}
 			// Convert tracked back to ProjectInfo for consistency
 			project := ProjectInfo{
+				ID:        tracked.ID,
 				Name:      tracked.Name,
 				URL:       tracked.URL,
 				Group:     tracked.Group,
@@ -153,24 +186,11 @@ func CompareWithInventory(tracker *ProjectTracker, currentProjects []ProjectInfo
 		}
 	}
 	
-	// Find unchanged projects (exist in both)
-	for key, current := range currentMap {
-		if tracked, exists := trackedMap[key]; exists {
-			// Check if the project has been moved or URL changed
-			expectedLocalPath := current.LocalPath
-			if tracked.LocalPath != expectedLocalPath {
-				// Project path changed, treat as modified
-				diff.ModifiedProjects = append(diff.ModifiedProjects, current)
-			} else {
-				diff.UnchangedProjects = append(diff.UnchangedProjects, current)
-			}
-		}
-	}
-	
 	// Log summary
 	logger.Info("📊 Analysis Results:")
 	logger.Info("   ➕ New projects: %d", len(diff.NewProjects))
 	logger.Info("   ➖ Removed projects: %d", len(diff.RemovedProjects))
+	logger.Info("   🧹 Orphaned clones (see 'syncx prune'): %d", orphanedCount)
 	logger.Info("   🔄 Modified projects: %d", len(diff.ModifiedProjects))
 	logger.Info("   ✅ Unchanged projects: %d", len(diff.UnchangedProjects))
 	
@@ -192,30 +212,26 @@ func CheckGitChanges(localPath string, logger *Logger) (bool, string, error) {
 	}
 
 	// Get current commit hash
-	currentHashCmd := exec.Command("git", "-C", localPath, "rev-parse", "HEAD")
-	currentHashOutput, err := currentHashCmd.Output()
+	currentHashOutput, err := RunGitCommand("-C", localPath, "rev-parse", "HEAD")
 	if err != nil {
 		return false, "", fmt.Errorf("failed to get current commit hash: %w", err)
 	}
 	currentHash := strings.TrimSpace(string(currentHashOutput))
 	
 	// Fast fetch from remote with timeout (only current branch)
-	if err := runGitCommandWithTimeout(20*time.Second, "-C", localPath, "fetch", "--quiet"); err != nil {
+	if _, _, err := runGitRemoteCommand(fetchTimeout(0), "-C", localPath, "fetch", "--quiet"); err != nil {
 		logger.Warning("Failed to fetch for %s: %v", localPath, err)
 		return false, currentHash, nil // Continue even if fetch fails
 	}
 	
 	// Get remote commit hash (assuming origin/main or origin/master)
-	remoteHashCmd := exec.Command("git", "-C", localPath, "rev-parse", "@{upstream}")
-	remoteHashOutput, err := remoteHashCmd.Output()
+	remoteHashOutput, err := RunGitCommand("-C", localPath, "rev-parse", "@{upstream}")
 	if err != nil {
 		// Try origin/main
-		remoteHashCmd = exec.Command("git", "-C", localPath, "rev-parse", "origin/main")
-		remoteHashOutput, err = remoteHashCmd.Output()
+		remoteHashOutput, err = RunGitCommand("-C", localPath, "rev-parse", "origin/main")
 		if err != nil {
 			// Try origin/master
-			remoteHashCmd = exec.Command("git", "-C", localPath, "rev-parse", "origin/master")
-			remoteHashOutput, err = remoteHashCmd.Output()
+			remoteHashOutput, err = RunGitCommand("-C", localPath, "rev-parse", "origin/master")
 			if err != nil {
 				logger.Warning("Could not determine remote HEAD for %s", localPath)
 				return false, currentHash, nil
@@ -236,21 +252,26 @@ func UpdateTrackedProject(tracker *ProjectTracker, project ProjectInfo, status s
 	now := time.Now().Format(time.RFC3339)
 	
 	// Find existing project
-	for i, tracked := range tracker.Projects {
-		if tracked.Name == project.Name && tracked.URL == project.URL {
-			// Update existing
-			tracker.Projects[i].LocalPath = project.LocalPath
-			tracker.Projects[i].GitURL = project.GitURL
-			tracker.Projects[i].Group = project.Group
-			tracker.Projects[i].LastUpdated = now
-			tracker.Projects[i].LastCommitHash = commitHash
-			tracker.Projects[i].Status = status
-			return
+	if i := FindTrackedProject(tracker, project); i >= 0 {
+		// Update existing
+		tracker.Projects[i].ID = ProjectIdentity(project)
+		tracker.Projects[i].Name = project.Name
+		tracker.Projects[i].URL = project.URL
+		tracker.Projects[i].LocalPath = project.LocalPath
+		tracker.Projects[i].GitURL = project.GitURL
+		tracker.Projects[i].Group = project.Group
+		tracker.Projects[i].LastUpdated = now
+		tracker.Projects[i].LastCommitHash = commitHash
+		tracker.Projects[i].Status = status
+		if status == "cloned" {
+			tracker.Projects[i].LastCloned = now
 		}
+		return
 	}
 	
 	// Add new project
 	tracked := TrackedProject{
+		ID:             ProjectIdentity(project),
 		Name:           project.Name,
 		URL:            project.URL,
 		Group:          project.Group,
@@ -264,28 +285,278 @@ func UpdateTrackedProject(tracker *ProjectTracker, project ProjectInfo, status s
 	tracker.Projects = append(tracker.Projects, tracked)
 }
 
+// RecordResultsInTracker stores the outcome of finished clone/pull operations in the tracker
+// Successful operations update the commit hash and the duration used for adaptive timeouts;
+// timed-out ones record their budget as a lower bound, so the next run gets a larger one
+func RecordResultsInTracker(outputDir, inventoryFile string, results []OperationResult) error {
+	tracker, err := LoadOrCreateTracker(outputDir, inventoryFile)
+	if err != nil {
+		return err
+	}
+
+	for _, result := range results {
+		if !result.Success {
+			if result.TimedOut {
+				recordTimedOutDuration(tracker, result)
+			}
+			continue
+		}
+
+		status := "updated"
+		if result.IsClone {
+			status = "cloned"
+		}
+		commitHash, _ := GetCurrentCommitHash(result.Project.LocalPath)
+		UpdateTrackedProject(tracker, result.Project, status, commitHash)
+
+		if i := FindTrackedProject(tracker, result.Project); i >= 0 {
+			if result.IsClone {
+				tracker.Projects[i].LastCloneDuration = result.Duration
+			} else {
+				tracker.Projects[i].LastPullDuration = result.Duration
+			}
+		}
+	}
+
+	return SaveTracker(tracker)
+}
+
+// recordTimedOutDuration raises the recorded clone/pull duration of a project whose last
+// attempt ran out of its budget: that attempt took at least the whole budget
+func recordTimedOutDuration(tracker *ProjectTracker, result OperationResult) {
+	budget := fetchTimeout(result.Project.FetchTimeout)
+	if result.IsClone {
+		budget = cloneTimeout(result.Project.CloneTimeout)
+	}
+
+	i := FindTrackedProject(tracker, result.Project)
+	if i < 0 {
+		// A first clone that timed out: keep an entry so the next attempt gets a larger budget
+		tracker.Projects = append(tracker.Projects, TrackedProject{
+			ID:        ProjectIdentity(result.Project),
+			Name:      result.Project.Name,
+			URL:       result.Project.URL,
+			Group:     result.Project.Group,
+			LocalPath: result.Project.LocalPath,
+			GitURL:    result.Project.GitURL,
+			Status:    "timed-out",
+		})
+		i = len(tracker.Projects) - 1
+	}
+
+	recorded := &tracker.Projects[i].LastPullDuration
+	if result.IsClone {
+		recorded = &tracker.Projects[i].LastCloneDuration
+	}
+	if previous, err := time.ParseDuration(*recorded); err == nil && previous >= budget {
+		return
+	}
+	*recorded = budget.String()
+}
+
 // RemoveTrackedProject removes a project from the tracker
 func RemoveTrackedProject(tracker *ProjectTracker, project ProjectInfo) {
+	identity := ProjectIdentity(project)
 	newProjects := []TrackedProject{}
 	for _, tracked := range tracker.Projects {
-		if tracked.Name != project.Name || tracked.URL != project.URL {
+		if trackedIdentity(tracked) != identity {
 			newProjects = append(newProjects, tracked)
 		}
 	}
 	tracker.Projects = newProjects
 }
 
+// ProjectIdentity is the stable key the tracker matches a project by: the inventory id when
+// one is set, otherwise the normalized remote URL, so the SSH, HTTPS and inventory spellings
+// of a repository (and a --protocol change) are the same project
+func ProjectIdentity(project ProjectInfo) string {
+	if project.ID != "" {
+		return project.ID
+	}
+	return NormalizeRemoteURL(project.URL)
+}
+
+// trackedIdentity is ProjectIdentity for a tracker entry
+func trackedIdentity(tracked TrackedProject) string {
+	if tracked.ID != "" {
+		return tracked.ID
+	}
+	return NormalizeRemoteURL(tracked.URL)
+}
+
+// FindTrackedProject returns the index of the project's tracker entry, or -1
+func FindTrackedProject(tracker *ProjectTracker, project ProjectInfo) int {
+	identity := ProjectIdentity(project)
+	for i, tracked := range tracker.Projects {
+		if trackedIdentity(tracked) == identity {
+			return i
+		}
+	}
+	return -1
+}
+
+// MatchTrackedProjects pairs inventory projects with tracker entries, keyed by project identity.
+// An entry whose identity is no longer in the inventory still matches the one unmatched project
+// with its name and group: that is a repository that moved to another namespace.
+func MatchTrackedProjects(tracker *ProjectTracker, projects []ProjectInfo) map[string]int {
+	matches := make(map[string]int)
+	claimed := make(map[int]bool)
+
+	byIdentity := make(map[string]int)
+	for i := len(tracker.Projects) - 1; i >= 0; i-- {
+		byIdentity[trackedIdentity(tracker.Projects[i])] = i
+	}
+
+	var unmatched []ProjectInfo
+	for _, project := range projects {
+		identity := ProjectIdentity(project)
+		if i, ok := byIdentity[identity]; ok {
+			matches[identity] = i
+			claimed[i] = true
+		} else {
+			unmatched = append(unmatched, project)
+		}
+	}
+
+	for _, project := range unmatched {
+		candidate := -1
+		for i, tracked := range tracker.Projects {
+			if claimed[i] || tracked.Name != project.Name || tracked.Group != project.Group {
+				continue
+			}
+			if candidate >= 0 {
+				candidate = -1 // ambiguous, treat the project as new
+				break
+			}
+			candidate = i
+		}
+		if candidate >= 0 {
+			matches[ProjectIdentity(project)] = candidate
+			claimed[candidate] = true
+		}
+	}
+	return matches
+}
+
+// markTrackedProjectOrphaned flags a project that left the inventory but is still on disk
+func markTrackedProjectOrphaned(tracker *ProjectTracker, project ProjectInfo) {
+	if i := FindTrackedProject(tracker, project); i >= 0 {
+		tracker.Projects[i].Status = "orphaned"
+	}
+}
+
 // GetCurrentCommitHash gets the current commit hash of a git repository
 func GetCurrentCommitHash(localPath string) (string, error) {
 	if !IsGitRepository(localPath) {
 		return "", fmt.Errorf("not a git repository: %s", localPath)
 	}
 	
-	cmd := exec.Command("git", "-C", localPath, "rev-parse", "HEAD")
-	output, err := cmd.Output()
+	output, err := RunGitCommand("-C", localPath, "rev-parse", "HEAD")
 	if err != nil {
 		return "", fmt.Errorf("failed to get commit hash: %w", err)
 	}
 	
 	return strings.TrimSpace(string(output)), nil
-}
\ No newline at end of file
+}
+
+// RebuildTracker regenerates the tracked projects from the repositories found on disk
+// Each repository is matched to an inventory project by its origin URL; known entries keep
+// their history (clone time, durations), entries whose repository is gone are dropped.
+func RebuildTracker(tracker *ProjectTracker, projects []ProjectInfo, repoPaths []string, protocol string) *TrackerRebuild {
+	rebuild := &TrackerRebuild{}
+
+	byRemote := make(map[string][]ProjectInfo)
+	for _, project := range projects {
+		key := NormalizeRemoteURL(project.URL)
+		byRemote[key] = append(byRemote[key], project)
+	}
+
+	previous := make(map[string]TrackedProject)
+	for _, tracked := range tracker.Projects {
+		previous[trackedIdentity(tracked)] = tracked
+	}
+	kept := make(map[string]bool)
+
+	matched := make(map[string]bool)
+	tracker.Projects = []TrackedProject{}
+	for _, path := range repoPaths {
+		remote, _ := GetRemoteURL(path)
+		project, ok := matchRepository(byRemote[NormalizeRemoteURL(remote)], path, tracker.OutputDirectory, matched)
+		if remote == "" || !ok {
+			rebuild.Untracked = append(rebuild.Untracked, UntrackedRepository{Path: path, RemoteURL: remote})
+			continue
+		}
+
+		matched[ProjectIdentity(project)] = true
+		project.LocalPath = path
+		project.GitURL = FormatGitURL(project.URL, protocol)
+		commitHash, _ := GetCurrentCommitHash(path)
+
+		// The repository is on disk now, so only the history of the old entry is kept
+		old, known := previous[ProjectIdentity(project)]
+		known = known && !kept[ProjectIdentity(project)]
+		if known {
+			kept[ProjectIdentity(project)] = true
+			tracker.Projects = append(tracker.Projects, TrackedProject{
+				ID:                ProjectIdentity(project),
+				LastCloneDuration: old.LastCloneDuration,
+				LastPullDuration:  old.LastPullDuration,
+			})
+		}
+		UpdateTrackedProject(tracker, project, "cloned", commitHash)
+		if known && old.LastCloned != "" {
+			tracker.Projects[len(tracker.Projects)-1].LastCloned = old.LastCloned
+		}
+		rebuild.Matched = append(rebuild.Matched, tracker.Projects[len(tracker.Projects)-1])
+	}
+
+	for _, project := range projects {
+		if !matched[ProjectIdentity(project)] {
+			rebuild.Missing = append(rebuild.Missing, project)
+		}
+	}
+	for identity, old := range previous {
+		if !kept[identity] {
+			rebuild.Removed = append(rebuild.Removed, old)
+		}
+	}
+	sort.Slice(rebuild.Removed, func(i, j int) bool { return rebuild.Removed[i].Name < rebuild.Removed[j].Name })
+
+	return rebuild
+}
+
+// matchRepository picks the inventory project a repository belongs to among those sharing its remote
+// The project whose computed path is the repository wins; otherwise the first one not matched yet
+func matchRepository(candidates []ProjectInfo, path, outputDir string, matched map[string]bool) (ProjectInfo, bool) {
+	var fallback *ProjectInfo
+	for i, project := range candidates {
+		if matched[ProjectIdentity(project)] {
+			continue
+		}
+		if CreateProjectLocalPath(outputDir, project.URL, project.Group) == path {
+			return project, true
+		}
+		if fallback == nil {
+			fallback = &candidates[i]
+		}
+	}
+	if fallback == nil {
+		return ProjectInfo{}, false
+	}
+	return *fallback, true
+}
+
+// BackupTracker copies the tracker file of the output directory to path, if there is one
+func BackupTracker(outputDir, path string) (bool, error) {
+	data, err := os.ReadFile(filepath.Join(outputDir, TrackingFileName))
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to read tracker file: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return false, fmt.Errorf("failed to back up tracker file: %w", err)
+	}
+	return true, nil
+}
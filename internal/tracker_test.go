@@ -0,0 +1,79 @@
+package internal
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestProjectIdentity(t *testing.T) {
+	tests := []struct {
+		name    string
+		project ProjectInfo
+		want    string
+	}{
+		{"inventory id wins", ProjectInfo{ID: "olive-api", URL: "gitlab.com:olive/api.git"}, "olive-api"},
+		{"inventory spelling", ProjectInfo{URL: "gitlab.com:olive/api.git"}, "gitlab.com/olive/api"},
+		{"ssh spelling", ProjectInfo{URL: "git@gitlab.com:olive/api.git"}, "gitlab.com/olive/api"},
+		{"https spelling", ProjectInfo{URL: "https://gitlab.com/olive/api"}, "gitlab.com/olive/api"},
+		{"name and group do not matter", ProjectInfo{Name: "api", Group: "Backend", URL: "gitlab.com:olive/api.git"}, "gitlab.com/olive/api"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ProjectIdentity(tt.project); got != tt.want {
+				t.Errorf("ProjectIdentity() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchTrackedProjects(t *testing.T) {
+	tracker := &ProjectTracker{Projects: []TrackedProject{
+		{ID: "gitlab.com/olive/api", Name: "api", Group: "Backend", URL: "gitlab.com:olive/api.git"},
+		{ID: "web-app", Name: "app", Group: "Frontend", URL: "gitlab.com:olive/app.git"},
+		{Name: "legacy", Group: "Backend", URL: "git@gitlab.com:olive/legacy.git"},
+		{ID: "gitlab.com/old/renamed", Name: "renamed", Group: "Backend", URL: "gitlab.com:old/renamed.git"},
+		{ID: "gitlab.com/a/twin", Name: "twin", Group: "Tools", URL: "gitlab.com:a/twin.git"},
+		{ID: "gitlab.com/b/twin", Name: "twin", Group: "Tools", URL: "gitlab.com:b/twin.git"},
+	}}
+
+	tests := []struct {
+		name    string
+		project ProjectInfo
+		want    int // index in the tracker, -1 when the project is new
+	}{
+		{"same repository over https", ProjectInfo{Name: "api", Group: "Backend", URL: "https://gitlab.com/olive/api.git"}, 0},
+		{"id survives a new url", ProjectInfo{ID: "web-app", Name: "app", Group: "Frontend", URL: "gitlab.com:newhome/app.git"}, 1},
+		{"entry without id matches by url", ProjectInfo{Name: "legacy", Group: "Backend", URL: "gitlab.com:olive/legacy.git"}, 2},
+		{"moved repository matches by name and group", ProjectInfo{Name: "renamed", Group: "Backend", URL: "gitlab.com:new/renamed.git"}, 3},
+		{"ambiguous name and group is new", ProjectInfo{Name: "twin", Group: "Tools", URL: "gitlab.com:c/twin.git"}, -1},
+		{"unknown project is new", ProjectInfo{Name: "fresh", Group: "Backend", URL: "gitlab.com:olive/fresh.git"}, -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			index, ok := MatchTrackedProjects(tracker, []ProjectInfo{tt.project})[ProjectIdentity(tt.project)]
+			if !ok {
+				index = -1
+			}
+			if index != tt.want {
+				t.Errorf("matched tracker entry %d, want %d", index, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchTrackedProjectsClaimsEachEntryOnce(t *testing.T) {
+	tracker := &ProjectTracker{Projects: []TrackedProject{
+		{ID: "gitlab.com/olive/api", Name: "api", Group: "Backend", URL: "gitlab.com:olive/api.git"},
+	}}
+	projects := []ProjectInfo{
+		{Name: "api", Group: "Backend", URL: "gitlab.com:olive/api.git"},
+		{Name: "api", Group: "Backend", URL: "gitlab.com:fork/api.git"},
+	}
+
+	want := map[string]int{"gitlab.com/olive/api": 0}
+	if got := MatchTrackedProjects(tracker, projects); !reflect.DeepEqual(got, want) {
+		t.Errorf("MatchTrackedProjects() = %v, want %v", got, want)
+	}
+}
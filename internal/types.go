@@ -1,18 +1,33 @@
 package internal
 
+import (
+	"sync"
+	"time"
+)
+
 // Project represents a single project with name and URL
 type Project struct {
-	Name string `json:"name"`
-	URL  string `json:"url"`
+	ID           string `json:"id,omitempty"` // Stable identity that survives URL changes (defaults to the URL)
+	Name         string `json:"name"`
+	URL          string `json:"url"`
+	CloneTimeout string `json:"clone_timeout,omitempty"` // Overrides --clone-timeout, e.g. "10m"
+	FetchTimeout string `json:"fetch_timeout,omitempty"` // Overrides --fetch-timeout, e.g. "2m"
+	Branch       string `json:"branch,omitempty"`        // Branch the working copy is expected to be on
 }
 
 // ProjectInfo represents extended project information
 type ProjectInfo struct {
-	Name      string
-	URL       string
-	GitURL    string
-	LocalPath string
-	Group     string
+	ID        string `json:"id,omitempty"`
+	Name      string `json:"name"`
+	URL       string `json:"url"`
+	GitURL    string `json:"git_url,omitempty"`
+	LocalPath string `json:"local_path,omitempty"`
+	Group     string `json:"group"`
+	Branch    string `json:"branch,omitempty"` // Expected branch from the inventory
+
+	// Effective budgets for network operations (zero means the global default)
+	CloneTimeout time.Duration `json:"-"`
+	FetchTimeout time.Duration `json:"-"`
 }
 
 // Group represents a group that can contain projects and/or subgroups
@@ -31,8 +46,11 @@ type InventoryRoot struct {
 
 // Inventory represents the root structure of the JSON file
 type Inventory struct {
-	PhysicalLocation string         `json:"phisical-location,omitempty"`
-	Root             *InventoryRoot `json:"root,omitempty"`
+	SchemaVersion    int    `json:"schema_version,omitempty"`
+	PhysicalLocation string `json:"physical-location,omitempty"`
+	// Misspelled key written by older versions, still read when the file could not be migrated
+	LegacyPhysicalLocation string         `json:"phisical-location,omitempty"`
+	Root                   *InventoryRoot `json:"root,omitempty"`
 	// Legacy support for old format (will be nil if new format is used)
 	Groups   []Group   `json:"groups,omitempty"`
 	Projects []Project `json:"projects,omitempty"`
@@ -40,43 +58,57 @@ type Inventory struct {
 
 // OperationResult represents the result of a clone/pull operation
 type OperationResult struct {
-	Success   bool
-	Project   ProjectInfo
-	Message   string
-	IsClone   bool
-	IsEmpty   bool // True if repository exists but has no commits
-	Duration  string
+	Success  bool        `json:"success"`
+	Project  ProjectInfo `json:"project"`
+	Message  string      `json:"message"`
+	IsClone  bool        `json:"is_clone"`
+	IsEmpty  bool        `json:"is_empty"` // True if repository exists but has no commits
+	Attempts int         `json:"attempts"` // Number of attempts made, more than 1 when transient failures were retried
+	Duration string      `json:"duration"`
+	TimedOut bool        `json:"timed_out,omitempty"` // True if the last attempt ran out of its budget
+
+	// HEAD before and after the operation (before is empty for a clone)
+	BeforeCommit string `json:"before_commit,omitempty"`
+	AfterCommit  string `json:"after_commit,omitempty"`
 }
 
 // Summary represents the final operation summary
 type Summary struct {
-	TotalProjects    int
-	SuccessCount     int
-	FailureCount     int
-	ClonedCount      int
-	UpdatedCount     int
-	SkippedCount     int
-	EmptyCount       int // Count of empty repositories (no commits)
-	TotalDuration    string
-	FailedProjects   []ProjectInfo
-	EmptyProjects    []ProjectInfo // Projects that are empty (no commits)
+	TotalProjects   int               `json:"total_projects"`
+	SuccessCount    int               `json:"success_count"`
+	FailureCount    int               `json:"failure_count"`
+	ClonedCount     int               `json:"cloned_count"`
+	UpdatedCount    int               `json:"updated_count"`
+	SkippedCount    int               `json:"skipped_count"`
+	EmptyCount      int               `json:"empty_count"` // Count of empty repositories (no commits)
+	TotalDuration   string            `json:"total_duration"`
+	FailedProjects  []ProjectInfo     `json:"failed_projects"`
+	EmptyProjects   []ProjectInfo     `json:"empty_projects"`        // Projects that are empty (no commits)
+	RetriedCount    int               `json:"retried_count"`         // Count of projects that succeeded only after retrying
+	RetriedProjects []ProjectInfo     `json:"retried_projects"`      // Projects that succeeded only after retrying
+	Results         []OperationResult `json:"results,omitempty"`     // Per-project results of the run
+	Relocations     []Relocation      `json:"relocations,omitempty"` // Clones moved to a new computed path
 }
 
 // TrackedProject represents a project that has been cloned with tracking info
 type TrackedProject struct {
-	Name          string    `json:"name"`
-	URL           string    `json:"url"`
-	Group         string    `json:"group"`
-	LocalPath     string    `json:"local_path"`
-	GitURL        string    `json:"git_url"`
-	LastCloned    string    `json:"last_cloned"`
-	LastUpdated   string    `json:"last_updated"`
-	LastCommitHash string   `json:"last_commit_hash"`
-	Status        string    `json:"status"` // "cloned", "updated", "error"
+	ID                string `json:"id"` // Stable identity, see ProjectIdentity
+	Name              string `json:"name"`
+	URL               string `json:"url"`
+	Group             string `json:"group"`
+	LocalPath         string `json:"local_path"`
+	GitURL            string `json:"git_url"`
+	LastCloned        string `json:"last_cloned"`
+	LastUpdated       string `json:"last_updated"`
+	LastCommitHash    string `json:"last_commit_hash"`
+	Status            string `json:"status"` // "cloned", "updated", "error"
+	LastCloneDuration string `json:"last_clone_duration,omitempty"`
+	LastPullDuration  string `json:"last_pull_duration,omitempty"`
 }
 
 // ProjectTracker represents the tracking file structure
 type ProjectTracker struct {
+	SchemaVersion   int              `json:"schema_version"`
 	LastSync        string           `json:"last_sync"`
 	OutputDirectory string           `json:"output_directory"`
 	InventoryFile   string           `json:"inventory_file"`
@@ -84,10 +116,84 @@ type ProjectTracker struct {
 	Projects        []TrackedProject `json:"projects"`
 }
 
+// TrackerRebuild reports what a tracker rebuild found on disk
+type TrackerRebuild struct {
+	Matched   []TrackedProject      `json:"matched"`   // Repositories matched to an inventory project
+	Missing   []ProjectInfo         `json:"missing"`   // Inventory projects with no repository on disk
+	Untracked []UntrackedRepository `json:"untracked"` // Repositories on disk that are not in the inventory
+	Removed   []TrackedProject      `json:"removed"`   // Previous entries whose repository is gone
+}
+
+// OrphanRepository is a clone in the output directory that no inventory project accounts for
+type OrphanRepository struct {
+	Path      string `json:"path"`
+	Name      string `json:"name"`
+	Group     string `json:"group,omitempty"`
+	RemoteURL string `json:"remote_url,omitempty"`
+	Reason    string `json:"reason"`
+}
+
+// UntrackedRepository is a repository on disk that no inventory project points to
+type UntrackedRepository struct {
+	Path      string `json:"path"`
+	RemoteURL string `json:"remote_url"`
+}
+
 // ProjectDiff represents differences between inventory and tracked state
 type ProjectDiff struct {
 	NewProjects     []ProjectInfo `json:"new_projects"`
 	RemovedProjects []ProjectInfo `json:"removed_projects"`
 	ModifiedProjects []ProjectInfo `json:"modified_projects"`
 	UnchangedProjects []ProjectInfo `json:"unchanged_projects"`
-}
\ No newline at end of file
+	// Tracked paths of the modified projects, keyed by project identity
+	PreviousPaths map[string]string `json:"previous_paths,omitempty"`
+}
+
+// Relocation describes moving a clone whose computed path changed
+type Relocation struct {
+	Project ProjectInfo `json:"project"`
+	From    string      `json:"from"`
+	To      string      `json:"to"`
+	Status  string      `json:"status"` // "moved", "would-move", "already-moved", "collision", "failed"
+	Detail  string      `json:"detail,omitempty"`
+}
+
+// JournalJob represents a single planned operation inside a run journal
+type JournalJob struct {
+	ID           string `json:"id,omitempty"`
+	Name         string `json:"name"`
+	URL          string `json:"url"`
+	Group        string `json:"group"`
+	GitURL       string `json:"git_url"`
+	LocalPath    string `json:"local_path"`
+	IsClone      bool   `json:"is_clone"`
+	State        string `json:"state"` // "pending", "done", "failed"
+	CloneTimeout string `json:"clone_timeout,omitempty"`
+	FetchTimeout string `json:"fetch_timeout,omitempty"`
+	Message      string `json:"message,omitempty"`
+}
+
+// RunJournal records the plan and progress of a clone/pull run so it can be resumed
+type RunJournal struct {
+	Command         string       `json:"command"` // "clone" or "pull"
+	StartedAt       string       `json:"started_at"`
+	UpdatedAt       string       `json:"updated_at"`
+	OutputDirectory string       `json:"output_directory"`
+	InventoryFile   string       `json:"inventory_file"`
+	Protocol        string       `json:"protocol"`
+	Parallel        int          `json:"parallel"`
+	Group           string       `json:"group,omitempty"`
+	Jobs            []JournalJob `json:"jobs"`
+
+	mu sync.Mutex
+}
+
+// LastRun records the outcome of the most recent clone/pull run
+type LastRun struct {
+	Command         string  `json:"command"`
+	StartedAt       string  `json:"started_at"`
+	FinishedAt      string  `json:"finished_at"`
+	OutputDirectory string  `json:"output_directory"`
+	InventoryFile   string  `json:"inventory_file"`
+	Summary         Summary `json:"summary"`
+}
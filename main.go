@@ -8,6 +8,8 @@ import (
 
 func main() {
 	if err := cmd.Execute(); err != nil {
-		os.Exit(1)
+		// Cobra only fails on invalid usage (unknown command or flag)
+		os.Exit(cmd.ExitConfigError)
 	}
-}
\ No newline at end of file
+	os.Exit(cmd.ExitCode())
+}